@@ -0,0 +1,181 @@
+// Package main is pocket-go, a command line tool to query the Pocket network, send transactions and relay
+// Underneath uses the packages Provider, TransactionBuilder and Relayer
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+const (
+	// rpcURLEnv is the environment variable holding the default RPC URL
+	rpcURLEnv = "POCKET_RPC_URL"
+	// dispatchersEnv is the environment variable holding the default comma separated dispatcher URLs
+	dispatchersEnv = "POCKET_DISPATCHERS"
+	// passwordEnv is the environment variable holding the password of the keyfile
+	passwordEnv = "POCKET_KEYFILE_PASSWORD"
+
+	defaultTimeout = 10 * time.Second
+)
+
+var (
+	errNoRPCURL       = errors.New("no rpc url provided, use -rpc or " + rpcURLEnv)
+	errNoCommand      = errors.New("no command provided")
+	errUnknownCommand = errors.New("unknown command")
+	errWrongArgs      = errors.New("wrong number of arguments")
+)
+
+// command represents a subcommand of the tool
+type command struct {
+	usage       string
+	description string
+	run         func(c *cli, flags *flag.FlagSet, args []string) error
+}
+
+var commands = map[string]command{
+	"height":       heightCommand,
+	"block":        blockCommand,
+	"tx":           txCommand,
+	"account":      accountCommand,
+	"node":         nodeCommand,
+	"app":          appCommand,
+	"app-capacity": appCapacityCommand,
+	"params":       paramsCommand,
+	"send":         sendCommand,
+	"sweep":        sweepCommand,
+	"stake-app":    stakeAppCommand,
+	"unstake-app":  unstakeAppCommand,
+	"unjail-app":   unjailAppCommand,
+	"stake-node":   stakeNodeCommand,
+	"unstake-node": unstakeNodeCommand,
+	"unjail-node":  unjailNodeCommand,
+	"payout":       payoutCommand,
+	"rebalance":    rebalanceCommand,
+	"relay":        relayCommand,
+}
+
+// cli holds the state shared by the commands
+type cli struct {
+	provider *provider.Provider
+	rpcURL   string
+	printer  *printer
+	stdin    io.Reader
+	getenv   func(string) string
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags and runs the command in args
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, getenv func(string) string) error {
+	globalFlags := flag.NewFlagSet("pocket-go", flag.ContinueOnError)
+	globalFlags.SetOutput(stderr)
+	globalFlags.Usage = func() { printUsage(globalFlags) }
+
+	rpcURL := globalFlags.String("rpc", getenv(rpcURLEnv), "URL of the Pocket node to send requests to, defaults to $"+rpcURLEnv)
+	dispatchers := globalFlags.String("dispatchers", getenv(dispatchersEnv), "comma separated dispatcher URLs, defaults to $"+dispatchersEnv+" or the rpc URL")
+	output := globalFlags.String("output", outputJSON, "output format, json or table")
+	timeout := globalFlags.Duration("timeout", defaultTimeout, "timeout of each request")
+	retries := globalFlags.Int("retries", 0, "retries of each failed request")
+
+	if err := globalFlags.Parse(args); err != nil {
+		return err
+	}
+
+	if globalFlags.NArg() == 0 {
+		globalFlags.Usage()
+		return errNoCommand
+	}
+
+	name := globalFlags.Arg(0)
+
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownCommand, name)
+	}
+
+	outputPrinter, err := newPrinter(stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	dispatcherURLs := []string{*rpcURL}
+	if *dispatchers != "" {
+		dispatcherURLs = strings.Split(*dispatchers, ",")
+	}
+
+	rpcProvider := provider.NewProvider(*rpcURL, dispatcherURLs)
+	rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{Timeout: *timeout, Retries: *retries})
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: pocket-go %s %s\n\n%s\n", name, cmd.usage, cmd.description)
+		flags.PrintDefaults()
+	}
+
+	return cmd.run(&cli{
+		provider: rpcProvider,
+		rpcURL:   *rpcURL,
+		printer:  outputPrinter,
+		stdin:    stdin,
+		getenv:   getenv,
+	}, flags, globalFlags.Args()[1:])
+}
+
+// parse parses the flags of a command and checks it got the expected number of arguments
+// The RPC URL is checked after the flags so the help of a command doesn't need it
+func (c *cli) parse(flags *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() < minArgs || flags.NArg() > maxArgs {
+		flags.Usage()
+		return errWrongArgs
+	}
+
+	if c.rpcURL == "" {
+		return errNoRPCURL
+	}
+
+	return nil
+}
+
+func printUsage(globalFlags *flag.FlagSet) {
+	out := globalFlags.Output()
+
+	fmt.Fprintln(out, "Usage: pocket-go [flags] <command> [command flags] [args]")
+	fmt.Fprintln(out, "\nCommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-14s %s\n", name, commands[name].description)
+	}
+
+	fmt.Fprintln(out, "\nFlags:")
+	globalFlags.PrintDefaults()
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+const testPassword = "password"
+
+func runCommand(t *testing.T, env map[string]string, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	err := run(args, strings.NewReader(stdin), &stdout, &stderr, func(key string) string { return env[key] })
+
+	return stdout.String(), err
+}
+
+func writeKeyfile(t *testing.T, account *signer.Signer) string {
+	c := require.New(t)
+
+	ppk, err := signer.NewPPK(account.GetPrivateKey(), testPassword, "")
+	c.NoError(err)
+
+	ppkFile, err := json.Marshal(ppk)
+	c.NoError(err)
+
+	path := filepath.Join(t.TempDir(), "keyfile.json")
+	c.NoError(os.WriteFile(path, ppkFile, 0o600))
+
+	return path
+}
+
+func TestRun_Queries(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{StartHeight: 7})
+	defer server.Close()
+
+	env := map[string]string{rpcURLEnv: server.URL}
+
+	output, err := runCommand(t, env, "", "height")
+	c.NoError(err)
+	c.JSONEq(`{"height": 7}`, output)
+
+	output, err = runCommand(t, env, "", "-output", "table", "height")
+	c.NoError(err)
+	c.Equal("height  7\n", output)
+
+	account, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(account.GetAddress(), 1000)
+
+	output, err = runCommand(t, env, "", "-output", "table", "account", account.GetAddress())
+	c.NoError(err)
+	c.Contains(output, "address         "+account.GetAddress())
+	c.Contains(output, "coins.0.amount  1000\n")
+
+	output, err = runCommand(t, env, "", "-output", "table", "params")
+	c.NoError(err)
+	c.Contains(output, "auth_params.auth/MaxMemoCharacters")
+
+	_, err = runCommand(t, env, "", "app", account.GetAddress())
+	c.ErrorContains(err, "application does not exist for that address")
+
+	_, err = runCommand(t, env, "", "block", "1", "2")
+	c.ErrorIs(err, errWrongArgs)
+
+	_, err = runCommand(t, env, "", "unknown")
+	c.ErrorIs(err, errUnknownCommand)
+
+	_, err = runCommand(t, nil, "", "height")
+	c.ErrorIs(err, errNoRPCURL)
+
+	_, err = runCommand(t, env, "", "-output", "yaml", "height")
+	c.ErrorIs(err, errUnknownOutput)
+}
+
+func TestRun_AppCapacity(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	env := map[string]string{rpcURLEnv: server.URL}
+
+	output, err := runCommand(t, env, "", "app-capacity", "-relays", "20001")
+	c.NoError(err)
+	c.JSONEq(`{"stake": 10000500, "max_relays": 20001}`, output)
+
+	output, err = runCommand(t, env, "", "app-capacity", "-stake", "10000000")
+	c.NoError(err)
+	c.JSONEq(`{"stake": 10000000, "max_relays": 20000}`, output)
+
+	_, err = runCommand(t, env, "", "app-capacity")
+	c.ErrorIs(err, errNoCapacity)
+}
+
+func TestRun_Transactions(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{AutoCommit: true})
+	defer server.Close()
+
+	account, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	receiver, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(account.GetAddress(), 20100000)
+
+	keyfile := writeKeyfile(t, account)
+	env := map[string]string{rpcURLEnv: server.URL}
+
+	_, err = runCommand(t, env, "", "send", "-keyfile", keyfile, "-chain-id", "localnet", receiver.GetAddress(), "100000")
+	c.ErrorIs(err, errNoPassword)
+
+	env[passwordEnv] = testPassword
+
+	output, err := runCommand(t, env, "", "send", "-keyfile", keyfile, "-chain-id", "localnet", receiver.GetAddress(), "100000")
+	c.NoError(err)
+	c.Contains(output, `"txhash"`)
+	c.Equal(int64(100000), server.Balance(receiver.GetAddress()).Int64())
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	c.NoError(os.WriteFile(passwordFile, []byte(testPassword+"\n"), 0o600))
+
+	_, err = runCommand(t, env, "", "stake-app", "-keyfile", keyfile, "-password-file", passwordFile, "-chain-id", "localnet", "10000000")
+	c.ErrorIs(err, errNoChains)
+
+	_, err = runCommand(t, env, "", "stake-app", "-keyfile", keyfile, "-password-file", passwordFile, "-chain-id", "localnet", "-chains", "0001,0021", "10000000")
+	c.NoError(err)
+
+	output, err = runCommand(t, env, "", "app", account.GetAddress())
+	c.NoError(err)
+
+	var app provider.App
+
+	c.NoError(json.Unmarshal([]byte(output), &app))
+	c.Equal([]string{"0001", "0021"}, app.Chains)
+	c.Equal("10000000", app.StakedTokens)
+
+	_, err = runCommand(t, env, "", "unstake-app", "-keyfile", keyfile, "-chain-id", "localnet")
+	c.NoError(err)
+
+	output, err = runCommand(t, env, "", "-output", "table", "app", account.GetAddress())
+	c.NoError(err)
+	c.Contains(output, "status          1\n")
+
+	output, err = runCommand(t, env, "", "sweep", "-keyfile", keyfile, "-chain-id", "localnet", receiver.GetAddress())
+	c.NoError(err)
+	c.Contains(output, `"amount"`)
+	c.Zero(server.Balance(account.GetAddress()).Int64())
+}
+
+func TestRun_Relay(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	server.SetChainBackend("0021", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal("application/json", r.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+
+	app, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	c.NoError(server.AddApp(provider.App{PublicKey: app.GetPublicKey(), Chains: []string{"0021"}, StakedTokens: "10000000"}))
+	c.NoError(server.AddNode(provider.Node{PublicKey: app.GetPublicKey(), Chains: []string{"0021"}}))
+
+	aat, err := json.Marshal(provider.PocketAAT{Version: "0.0.1", AppPubKey: app.GetPublicKey(), ClientPubKey: app.GetPublicKey()})
+	c.NoError(err)
+
+	aatFile := filepath.Join(t.TempDir(), "aat.json")
+	c.NoError(os.WriteFile(aatFile, aat, 0o600))
+
+	env := map[string]string{rpcURLEnv: server.URL, passwordEnv: testPassword}
+	data := `{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`
+
+	output, err := runCommand(t, env, data, "relay", "-keyfile", writeKeyfile(t, app), "-aat", aatFile,
+		"-header", "Content-Type=application/json", "0021", "-")
+	c.NoError(err)
+
+	var relayOutput provider.RelayOutput
+
+	c.NoError(json.Unmarshal([]byte(output), &relayOutput))
+	c.Equal(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`, relayOutput.Response)
+
+	_, err = runCommand(t, env, "", "relay", "-aat", aatFile, "0021", data)
+	c.ErrorIs(err, errNoKeyfile)
+}
+
+func TestRun_Payout(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{AutoCommit: true})
+	defer server.Close()
+
+	account, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	receiver, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(account.GetAddress(), 1000000)
+
+	keyfile := writeKeyfile(t, account)
+	env := map[string]string{rpcURLEnv: server.URL, passwordEnv: testPassword}
+
+	manifest := filepath.Join(t.TempDir(), "payouts.csv")
+	c.NoError(os.WriteFile(manifest, []byte("recipient,amount,memo\n"+receiver.GetAddress()+",100,week 1\n"+receiver.GetAddress()+",200,week 2\n"), 0o600))
+
+	output, err := runCommand(t, env, "", "payout", "-keyfile", keyfile, "-chain-id", "localnet", manifest)
+	c.NoError(err)
+	c.Contains(output, `"submitted": 2`)
+	c.Equal(int64(300), server.Balance(receiver.GetAddress()).Int64())
+
+	_, err = os.Stat(manifest + ".ledger")
+	c.NoError(err)
+
+	output, err = runCommand(t, env, "", "payout", "-keyfile", keyfile, "-chain-id", "localnet", manifest)
+	c.NoError(err)
+	c.Contains(output, `"confirmed": 2`)
+	c.Equal(int64(300), server.Balance(receiver.GetAddress()).Int64())
+}
+
+func TestRun_Rebalance(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{AutoCommit: true})
+	defer server.Close()
+
+	server.SetChainBackend("0001", http.NotFoundHandler())
+
+	app, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(app.GetAddress(), 10000000)
+
+	keyfile := writeKeyfile(t, app)
+	env := map[string]string{rpcURLEnv: server.URL, passwordEnv: testPassword}
+
+	config := filepath.Join(filepath.Dir(keyfile), "rebalance.json")
+	c.NoError(os.WriteFile(config, []byte(`{"apps":[{"keyfile":"keyfile.json"}],"min_relays":1000,"demand":{"0001":5000}}`), 0o600))
+
+	output, err := runCommand(t, env, "", "rebalance", "-chain-id", "localnet", "-dry-run", config)
+	c.NoError(err)
+	c.Contains(output, `"dry_run": true`)
+	c.Contains(output, `"stake": 2500000`)
+	c.Equal(int64(10000000), server.Balance(app.GetAddress()).Int64())
+
+	_, err = runCommand(t, env, "", "rebalance", "-chain-id", "localnet", config)
+	c.NoError(err)
+
+	output, err = runCommand(t, env, "", "app", app.GetAddress())
+	c.NoError(err)
+	c.Contains(output, `"max_relays": "5000"`)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+const (
+	outputJSON  = "json"
+	outputTable = "table"
+)
+
+var errUnknownOutput = errors.New("unknown output format, use json or table")
+
+// printer writes command results in the chosen output format
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+func newPrinter(out io.Writer, format string) (*printer, error) {
+	if format != outputJSON && format != outputTable {
+		return nil, errUnknownOutput
+	}
+
+	return &printer{out: out, format: format}, nil
+}
+
+// print writes value as indented JSON or as a table of its fields
+// Nested fields are flattened with dot separated names, i.e. coins.0.amount
+func (p *printer) print(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if p.format == outputJSON {
+		var buffer bytes.Buffer
+
+		if err := json.Indent(&buffer, data, "", "  "); err != nil {
+			return err
+		}
+
+		buffer.WriteByte('\n')
+
+		_, err := buffer.WriteTo(p.out)
+		return err
+	}
+
+	rows, err := flatten(data)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+
+	for _, row := range rows {
+		if row[0] == "" {
+			fmt.Fprintln(writer, row[1])
+			continue
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\n", row[0], row[1])
+	}
+
+	return writer.Flush()
+}
+
+// flatten returns the name and value of every leaf of the JSON document, keeping the order of the fields
+func flatten(data []byte) ([][2]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var rows [][2]string
+
+	if err := flattenValue(decoder, "", &rows); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func flattenValue(decoder *json.Decoder, name string, rows *[][2]string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		*rows = append(*rows, [2]string{name, formatToken(token)})
+		return nil
+	}
+
+	empty := true
+
+	for i := 0; decoder.More(); i++ {
+		empty = false
+		key := strconv.Itoa(i)
+
+		if delim == '{' {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+
+			key = keyToken.(string)
+		}
+
+		if name != "" {
+			key = name + "." + key
+		}
+
+		if err := flattenValue(decoder, key, rows); err != nil {
+			return err
+		}
+	}
+
+	// Closing delimiter
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	if empty {
+		emptyValue := "[]"
+		if delim == '{' {
+			emptyValue = "{}"
+		}
+
+		*rows = append(*rows, [2]string{name, emptyValue})
+	}
+
+	return nil
+}
+
+func formatToken(token json.Token) string {
+	switch value := token.(type) {
+	case nil:
+		return "null"
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
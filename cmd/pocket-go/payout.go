@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/pokt-foundation/pocket-go/payout"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+	"github.com/pokt-foundation/pocket-go/utils"
+)
+
+var payoutCommand = command{
+	usage:       "[flags] <manifest>",
+	description: "Pays the recipients of a CSV or JSON manifest, running it again continues an interrupted payout",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		keyfile := addKeyfileFlags(flags)
+		chainID := flags.String("chain-id", string(transactionbuilder.Mainnet), "chain id of the network, mainnet, testnet or localnet")
+		fee := flags.Int64("fee", 0, "fee of every transaction in upokt, defaults to the minimum fee")
+		ledgerPath := flags.String("ledger", "", "path to the ledger of the payout, defaults to the manifest path with .ledger appended")
+		maxInFlight := flags.Int("max-in-flight", 0, "transactions submitted at once, defaults to 10")
+		wait := flags.Bool("wait", false, "wait until every transaction is included in a block")
+
+		if err := c.parse(flags, args, 1, 1); err != nil {
+			return err
+		}
+
+		payments, err := payout.ReadManifest(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		txSigner, err := keyfile.signer(c)
+		if err != nil {
+			return err
+		}
+
+		queueConfig := transactionbuilder.QueueConfig{
+			ChainID:     transactionbuilder.ChainID(*chainID),
+			MaxInFlight: *maxInFlight,
+		}
+
+		if *wait {
+			queueConfig.WaitOptions = &transactionbuilder.WaitOptions{}
+		}
+
+		txPayout, err := payout.NewPayout(payout.Config{
+			Provider:           c.provider,
+			TransactionBuilder: transactionbuilder.NewTransactionBuilder(c.provider, txSigner),
+			FromAddress:        txSigner.GetAddress(),
+			Fee:                *fee,
+			Queue:              queueConfig,
+		})
+		if err != nil {
+			return err
+		}
+
+		if *ledgerPath == "" {
+			*ledgerPath = flags.Arg(0) + ".ledger"
+		}
+
+		ledger, err := payout.OpenLedger(*ledgerPath)
+		if err != nil {
+			return err
+		}
+		defer utils.CloseOrLog(ledger)
+
+		report, err := txPayout.Run(payments, ledger)
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(report)
+	},
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"strconv"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+)
+
+var errNoCapacity = errors.New("use either -relays or -stake")
+
+var heightCommand = command{
+	usage:       "",
+	description: "Returns the current block height",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		if err := c.parse(flags, args, 0, 0); err != nil {
+			return err
+		}
+
+		height, err := c.provider.GetBlockHeight()
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(map[string]int{"height": height})
+	},
+}
+
+var blockCommand = command{
+	usage:       "[height]",
+	description: "Returns the block at the height, defaults to the latest block",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		if err := c.parse(flags, args, 0, 1); err != nil {
+			return err
+		}
+
+		height, err := parseHeight(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		block, err := c.provider.GetBlock(height)
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(block)
+	},
+}
+
+var txCommand = command{
+	usage:       "[flags] <hash>",
+	description: "Returns the transaction with the hash",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		prove := flags.Bool("prove", false, "include the proof of the transaction")
+
+		if err := c.parse(flags, args, 1, 1); err != nil {
+			return err
+		}
+
+		tx, err := c.provider.GetTransaction(flags.Arg(0), &provider.GetTransactionOptions{Prove: *prove})
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(tx)
+	},
+}
+
+var accountCommand = command{
+	usage:       "[flags] <address>",
+	description: "Returns the account with the address",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		height := flags.Int("height", 0, "height of the query, defaults to the latest block")
+
+		if err := c.parse(flags, args, 1, 1); err != nil {
+			return err
+		}
+
+		account, err := c.provider.GetAccount(flags.Arg(0), &provider.GetAccountOptions{Height: *height})
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(account)
+	},
+}
+
+var nodeCommand = command{
+	usage:       "[flags] <address>",
+	description: "Returns the node with the address",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		height := flags.Int("height", 0, "height of the query, defaults to the latest block")
+
+		if err := c.parse(flags, args, 1, 1); err != nil {
+			return err
+		}
+
+		node, err := c.provider.GetNode(flags.Arg(0), &provider.GetNodeOptions{Height: *height})
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(node)
+	},
+}
+
+var appCommand = command{
+	usage:       "[flags] <address>",
+	description: "Returns the app with the address",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		height := flags.Int("height", 0, "height of the query, defaults to the latest block")
+
+		if err := c.parse(flags, args, 1, 1); err != nil {
+			return err
+		}
+
+		app, err := c.provider.GetApp(flags.Arg(0), &provider.GetAppOptions{Height: *height})
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(app)
+	},
+}
+
+var appCapacityCommand = command{
+	usage:       "[flags]",
+	description: "Returns the app stake in upokt needed for relays per session, or the relays per session of a stake",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		relays := flags.Int64("relays", 0, "relays per session the app needs")
+		stake := flags.Int64("stake", 0, "upokt the app stakes")
+		participationRate := flags.Float64("participation-rate", 0, "staked tokens over the total supply, needed when application/ParticipationRateOn is true")
+
+		if err := c.parse(flags, args, 0, 0); err != nil {
+			return err
+		}
+
+		if (*relays == 0) == (*stake == 0) {
+			return errNoCapacity
+		}
+
+		calculator := transactionbuilder.NewAppStakeCalculator(c.provider)
+
+		if *participationRate != 0 {
+			calculator.SetParticipationRate(*participationRate)
+		}
+
+		if *relays != 0 {
+			var err error
+
+			*stake, err = calculator.RequiredStake(*relays)
+			if err != nil {
+				return err
+			}
+		}
+
+		maxRelays, err := calculator.MaxRelays(*stake)
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(map[string]int64{"stake": *stake, "max_relays": maxRelays})
+	},
+}
+
+var paramsCommand = command{
+	usage:       "[flags]",
+	description: "Returns the params of the network",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		height := flags.Int("height", 0, "height of the query, defaults to the latest block")
+
+		if err := c.parse(flags, args, 0, 0); err != nil {
+			return err
+		}
+
+		params, err := c.provider.GetAllParams(&provider.GetAllParamsOptions{Height: *height})
+		if err != nil {
+			return err
+		}
+
+		// Keyed by param name so the table reads i.e. node_params.pos/StakeMinimum
+		return c.printer.print(map[string]map[string]string{
+			"app_params":    paramValues(params.AppParams),
+			"auth_params":   paramValues(params.AuthParams),
+			"gov_params":    paramValues(params.GovParams),
+			"node_params":   paramValues(params.NodeParams),
+			"pocket_params": paramValues(params.PocketParams),
+		})
+	},
+}
+
+func paramValues(group provider.ParamGroup) map[string]string {
+	values := make(map[string]string, len(group))
+
+	for _, param := range group {
+		values[param.Key] = param.Value
+	}
+
+	return values
+}
+
+// parseHeight returns the height in arg, zero when empty which stands for the latest block
+func parseHeight(arg string) (int, error) {
+	if arg == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(arg)
+}
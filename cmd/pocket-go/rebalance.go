@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/pokt-foundation/pocket-go/rebalancer"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+)
+
+// rebalanceConfig represents the JSON config of the rebalance command
+type rebalanceConfig struct {
+	Apps []struct {
+		// Keyfile is relative to the config file
+		Keyfile string   `json:"keyfile"`
+		Chains  []string `json:"chains"`
+	} `json:"apps"`
+	MinRelays    int64            `json:"min_relays"`
+	TargetRelays int64            `json:"target_relays"`
+	Demand       map[string]int64 `json:"demand"`
+}
+
+var rebalanceCommand = command{
+	usage:       `[flags] <config>, i.e. {"apps":[{"keyfile":"app.json","chains":["0001"]}],"min_relays":1000,"demand":{"0001":5000}}`,
+	description: "Stakes, tops up and moves the chains of the apps of a JSON config, apps without chains get them from the demand",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		passwordFile := flags.String("password-file", "", "path to a file holding the password of every keyfile, defaults to $"+passwordEnv)
+		chainID := flags.String("chain-id", string(transactionbuilder.Mainnet), "chain id of the network, mainnet, testnet or localnet")
+		fee := flags.Int64("fee", 0, "fee of every transaction in upokt, defaults to the minimum fee")
+		participationRate := flags.Float64("participation-rate", 0, "staked tokens over the total supply, needed when application/ParticipationRateOn is true")
+		dryRun := flags.Bool("dry-run", false, "print the planned actions without submitting them")
+
+		if err := c.parse(flags, args, 1, 1); err != nil {
+			return err
+		}
+
+		configFile, err := os.ReadFile(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		var config rebalanceConfig
+
+		if err := json.Unmarshal(configFile, &config); err != nil {
+			return err
+		}
+
+		password, err := (&keyfileFlags{passwordFile: passwordFile}).password(c)
+		if err != nil {
+			return err
+		}
+
+		apps := make([]rebalancer.App, len(config.Apps))
+
+		for i, app := range config.Apps {
+			keyfile := app.Keyfile
+			if !filepath.IsAbs(keyfile) {
+				keyfile = filepath.Join(filepath.Dir(flags.Arg(0)), keyfile)
+			}
+
+			appSigner, err := readKeyfile(keyfile, password)
+			if err != nil {
+				return err
+			}
+
+			apps[i] = rebalancer.App{Signer: appSigner, Chains: app.Chains}
+		}
+
+		rebalancerConfig := rebalancer.Config{
+			Provider:          c.provider,
+			ChainID:           transactionbuilder.ChainID(*chainID),
+			Apps:              apps,
+			MinRelays:         config.MinRelays,
+			TargetRelays:      config.TargetRelays,
+			ParticipationRate: *participationRate,
+			Fee:               *fee,
+			DryRun:            *dryRun,
+		}
+
+		if config.Demand != nil {
+			rebalancerConfig.Demand = func(context.Context) (map[string]int64, error) {
+				return config.Demand, nil
+			}
+		}
+
+		appRebalancer, err := rebalancer.NewRebalancer(rebalancerConfig)
+		if err != nil {
+			return err
+		}
+
+		plan, err := appRebalancer.Rebalance()
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(plan)
+	},
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relayer"
+)
+
+var (
+	errNoAAT          = errors.New("no aat provided, use -aat")
+	errInvalidHeader  = errors.New("invalid header, use name=value")
+	errNoSessionNodes = errors.New("dispatched session has no nodes")
+)
+
+// headerFlag represents a repeatable name=value flag
+type headerFlag provider.RelayHeaders
+
+func (h headerFlag) String() string {
+	return fmt.Sprint(map[string]string(h))
+}
+
+func (h headerFlag) Set(value string) error {
+	name, headerValue, ok := strings.Cut(value, "=")
+	if !ok {
+		return errInvalidHeader
+	}
+
+	h[name] = headerValue
+
+	return nil
+}
+
+var relayCommand = command{
+	usage:       "[flags] <chain> <data>",
+	description: "Relays data to a node of the session of the AAT app, data - reads it from stdin",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		keyfile := addKeyfileFlags(flags)
+		aatFile := flags.String("aat", "", "path to the AAT JSON file, signed for the keyfile account as client")
+		method := flags.String("method", "", "HTTP method of the relay, defaults to POST")
+		path := flags.String("path", "", "path of the relay, i.e. /v1/query/height for Pocket relays")
+		headers := headerFlag{}
+		flags.Var(headers, "header", "header of the relay as name=value, can be repeated")
+
+		if err := c.parse(flags, args, 2, 2); err != nil {
+			return err
+		}
+
+		aat, err := readAAT(*aatFile)
+		if err != nil {
+			return err
+		}
+
+		data, err := relayData(c.stdin, flags.Arg(1))
+		if err != nil {
+			return err
+		}
+
+		clientSigner, err := keyfile.signer(c)
+		if err != nil {
+			return err
+		}
+
+		chain := flags.Arg(0)
+
+		dispatch, err := c.provider.Dispatch(aat.AppPubKey, chain, nil)
+		if err != nil {
+			return err
+		}
+
+		if dispatch.Session == nil || len(dispatch.Session.Nodes) == 0 {
+			return errNoSessionNodes
+		}
+
+		node, err := relayer.GetRandomSessionNode(dispatch.Session)
+		if err != nil {
+			return err
+		}
+
+		output, err := relayer.NewRelayer(clientSigner, c.provider).Relay(&relayer.Input{
+			Blockchain: chain,
+			Data:       data,
+			Headers:    provider.RelayHeaders(headers),
+			Method:     *method,
+			Node:       node,
+			Path:       *path,
+			PocketAAT:  aat,
+			Session:    dispatch.Session,
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(output.RelayOutput)
+	},
+}
+
+func readAAT(path string) (*provider.PocketAAT, error) {
+	if path == "" {
+		return nil, errNoAAT
+	}
+
+	aatFile, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var aat provider.PocketAAT
+
+	if err := json.Unmarshal(aatFile, &aat); err != nil {
+		return nil, err
+	}
+
+	return &aat, nil
+}
+
+// relayData returns the data argument, reading stdin when it is -
+func relayData(stdin io.Reader, arg string) (string, error) {
+	if arg != "-" {
+		return arg, nil
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pokt-foundation/pocket-go/signer"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+)
+
+var (
+	errNoKeyfile  = errors.New("no keyfile provided, use -keyfile")
+	errNoPassword = errors.New("no keyfile password provided, use -password-file or " + passwordEnv)
+	errNoChains   = errors.New("no chains provided, use -chains")
+)
+
+// keyfileFlags represents the flags to load a signer from a PPK keyfile
+type keyfileFlags struct {
+	keyfile      *string
+	passwordFile *string
+}
+
+func addKeyfileFlags(flags *flag.FlagSet) *keyfileFlags {
+	return &keyfileFlags{
+		keyfile:      flags.String("keyfile", "", "path to the PPK keyfile of the account, as exported by pocket-core"),
+		passwordFile: flags.String("password-file", "", "path to a file holding the keyfile password, defaults to $"+passwordEnv),
+	}
+}
+
+// signer returns the signer of the keyfile
+func (f *keyfileFlags) signer(c *cli) (*signer.Signer, error) {
+	if *f.keyfile == "" {
+		return nil, errNoKeyfile
+	}
+
+	password, err := f.password(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return readKeyfile(*f.keyfile, password)
+}
+
+// password returns the password of the keyfile from the password file or the environment
+func (f *keyfileFlags) password(c *cli) (string, error) {
+	password := c.getenv(passwordEnv)
+
+	if *f.passwordFile != "" {
+		passwordFile, err := os.ReadFile(*f.passwordFile)
+		if err != nil {
+			return "", err
+		}
+
+		password = strings.TrimRight(string(passwordFile), "\r\n")
+	}
+
+	if password == "" {
+		return "", errNoPassword
+	}
+
+	return password, nil
+}
+
+// readKeyfile returns the signer of the PPK keyfile at the path
+func readKeyfile(path, password string) (*signer.Signer, error) {
+	ppkFile, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ppk signer.PPK
+
+	if err := json.Unmarshal(ppkFile, &ppk); err != nil {
+		return nil, err
+	}
+
+	return signer.NewSignerFromPPK(password, &ppk)
+}
+
+// transactionFlags represents the flags shared by the transaction commands
+type transactionFlags struct {
+	*keyfileFlags
+	chainID *string
+	fee     *int64
+	memo    *string
+}
+
+func addTransactionFlags(flags *flag.FlagSet) *transactionFlags {
+	return &transactionFlags{
+		keyfileFlags: addKeyfileFlags(flags),
+		chainID:      flags.String("chain-id", string(transactionbuilder.Mainnet), "chain id of the network, mainnet, testnet or localnet"),
+		fee:          flags.Int64("fee", 0, "fee of the transaction in upokt, defaults to the minimum fee"),
+		memo:         flags.String("memo", "", "memo of the transaction"),
+	}
+}
+
+// message returns the message of a transaction command given the signer and the parsed flags
+type message func(txSigner *signer.Signer, flags *flag.FlagSet) (transactionbuilder.TransactionMessage, error)
+
+// newTransactionCommand returns a command that submits the message built by msg
+// addFlags adds the flags specific to the message and can be nil
+func newTransactionCommand(usage, description string, args int, addFlags func(flags *flag.FlagSet), msg message) command {
+	return command{
+		usage:       usage,
+		description: description,
+		run: func(c *cli, flags *flag.FlagSet, rawArgs []string) error {
+			txFlags := addTransactionFlags(flags)
+
+			if addFlags != nil {
+				addFlags(flags)
+			}
+
+			if err := c.parse(flags, rawArgs, args, args); err != nil {
+				return err
+			}
+
+			txSigner, err := txFlags.signer(c)
+			if err != nil {
+				return err
+			}
+
+			txMsg, err := msg(txSigner, flags)
+			if err != nil {
+				return err
+			}
+
+			output, err := transactionbuilder.NewTransactionBuilder(c.provider, txSigner).Submit(
+				transactionbuilder.ChainID(*txFlags.chainID),
+				txMsg,
+				&transactionbuilder.TransactionOptions{Memo: *txFlags.memo, Fee: *txFlags.fee},
+			)
+			if err != nil {
+				return err
+			}
+
+			return c.printer.print(output)
+		},
+	}
+}
+
+var sendCommand = newTransactionCommand("[flags] <to address> <amount>", "Sends upokt from the keyfile account", 2, nil,
+	func(txSigner *signer.Signer, flags *flag.FlagSet) (transactionbuilder.TransactionMessage, error) {
+		amount, err := strconv.ParseInt(flags.Arg(1), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		return transactionbuilder.NewSend(txSigner.GetAddress(), flags.Arg(0), amount)
+	},
+)
+
+var sweepCommand = command{
+	usage:       "[flags] <to address>",
+	description: "Sends the whole balance of the keyfile account minus the fee and waits until it is included",
+	run: func(c *cli, flags *flag.FlagSet, args []string) error {
+		txFlags := addTransactionFlags(flags)
+
+		if err := c.parse(flags, args, 1, 1); err != nil {
+			return err
+		}
+
+		txSigner, err := txFlags.signer(c)
+		if err != nil {
+			return err
+		}
+
+		txBuilder := transactionbuilder.NewTransactionBuilder(c.provider, txSigner)
+		txBuilder.SetFeeCalculator(transactionbuilder.NewFeeSchedule(c.provider))
+
+		output, err := txBuilder.Sweep(
+			transactionbuilder.ChainID(*txFlags.chainID),
+			flags.Arg(0),
+			&transactionbuilder.TransactionOptions{Memo: *txFlags.memo, Fee: *txFlags.fee},
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+
+		return c.printer.print(output)
+	},
+}
+
+var stakeAppCommand = newTransactionCommand("[flags] <amount>", "Stakes the keyfile account as an app, also edits its stake", 1,
+	func(flags *flag.FlagSet) {
+		flags.String("chains", "", "comma separated chains to stake for, i.e. 0001,0021")
+	},
+	func(txSigner *signer.Signer, flags *flag.FlagSet) (transactionbuilder.TransactionMessage, error) {
+		amount, chains, err := stakeArgs(flags)
+		if err != nil {
+			return nil, err
+		}
+
+		return transactionbuilder.NewStakeApp(txSigner.GetPublicKey(), chains, amount)
+	},
+)
+
+var unstakeAppCommand = newTransactionCommand("[flags]", "Unstakes the keyfile app", 0, nil,
+	func(txSigner *signer.Signer, _ *flag.FlagSet) (transactionbuilder.TransactionMessage, error) {
+		return transactionbuilder.NewUnstakeApp(txSigner.GetAddress())
+	},
+)
+
+var unjailAppCommand = newTransactionCommand("[flags]", "Unjails the keyfile app", 0, nil,
+	func(txSigner *signer.Signer, _ *flag.FlagSet) (transactionbuilder.TransactionMessage, error) {
+		return transactionbuilder.NewUnjailApp(txSigner.GetAddress())
+	},
+)
+
+var stakeNodeCommand = newTransactionCommand("[flags] <amount>", "Stakes the keyfile account as a node, also edits its stake", 1,
+	func(flags *flag.FlagSet) {
+		flags.String("chains", "", "comma separated chains to stake for, i.e. 0001,0021")
+		flags.String("service-url", "", "URL the node serves relays at, i.e. https://node.example.com:443")
+		flags.String("output-address", "", "address receiving the rewards and unstaked tokens, defaults to the keyfile account")
+	},
+	func(txSigner *signer.Signer, flags *flag.FlagSet) (transactionbuilder.TransactionMessage, error) {
+		amount, chains, err := stakeArgs(flags)
+		if err != nil {
+			return nil, err
+		}
+
+		outputAddress := flagValue(flags, "output-address")
+		if outputAddress == "" {
+			outputAddress = txSigner.GetAddress()
+		}
+
+		return transactionbuilder.NewStakeNode(txSigner.GetPublicKey(), flagValue(flags, "service-url"), outputAddress, chains, amount)
+	},
+)
+
+var unstakeNodeCommand = newTransactionCommand("[flags]", "Unstakes a node, signed by its operator or output address", 0,
+	addOperatorFlag,
+	func(txSigner *signer.Signer, flags *flag.FlagSet) (transactionbuilder.TransactionMessage, error) {
+		return transactionbuilder.NewUnstakeNode(txSigner.GetAddress(), operatorAddress(txSigner, flags))
+	},
+)
+
+var unjailNodeCommand = newTransactionCommand("[flags]", "Unjails a node, signed by its operator or output address", 0,
+	addOperatorFlag,
+	func(txSigner *signer.Signer, flags *flag.FlagSet) (transactionbuilder.TransactionMessage, error) {
+		return transactionbuilder.NewUnjailNode(txSigner.GetAddress(), operatorAddress(txSigner, flags))
+	},
+)
+
+func addOperatorFlag(flags *flag.FlagSet) {
+	flags.String("operator", "", "address of the node, defaults to the keyfile account")
+}
+
+func operatorAddress(txSigner *signer.Signer, flags *flag.FlagSet) string {
+	if operator := flagValue(flags, "operator"); operator != "" {
+		return operator
+	}
+
+	return txSigner.GetAddress()
+}
+
+// stakeArgs returns the amount argument and the chains flag of a stake command
+func stakeArgs(flags *flag.FlagSet) (int64, []string, error) {
+	amount, err := strconv.ParseInt(flags.Arg(0), 10, 64)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	chains := flagValue(flags, "chains")
+	if chains == "" {
+		return 0, nil, errNoChains
+	}
+
+	return amount, strings.Split(chains, ","), nil
+}
+
+func flagValue(flags *flag.FlagSet, name string) string {
+	return flags.Lookup(name).Value.String()
+}
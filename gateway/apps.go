@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+// RandomAppSelector selects a random app from a fixed list for every relay
+// All the apps are expected to be staked for every chain served by the gateway
+type RandomAppSelector struct {
+	aats []*provider.PocketAAT
+}
+
+// NewRandomAppSelector returns an instance of RandomAppSelector from the given AATs
+func NewRandomAppSelector(aats ...*provider.PocketAAT) *RandomAppSelector {
+	return &RandomAppSelector{
+		aats: aats,
+	}
+}
+
+// SelectApp returns the AAT of a random app
+func (s *RandomAppSelector) SelectApp(chain string) (*provider.PocketAAT, error) {
+	if len(s.aats) == 0 {
+		return nil, ErrNoApps
+	}
+
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(len(s.aats))))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.aats[index.Int64()], nil
+}
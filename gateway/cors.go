@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORSConfig represents the Cross-Origin Resource Sharing settings of the gateway
+type CORSConfig struct {
+	// AllowedOrigins lists the origins allowed to do requests, "*" allows any origin
+	AllowedOrigins []string
+	// AllowedHeaders lists the headers allowed on requests, defaults to Content-Type
+	AllowedHeaders []string
+	// MaxAge is the amount of seconds a preflight response can be cached, zero omits the header
+	MaxAge int
+}
+
+var (
+	defaultAllowedHeaders = []string{"Content-Type"}
+	allowedMethods        = strings.Join([]string{http.MethodGet, http.MethodPost, http.MethodOptions}, ", ")
+)
+
+func (c *CORSConfig) allowedOrigin(origin string) (string, bool) {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return "*", true
+		}
+
+		if strings.EqualFold(allowed, origin) {
+			return origin, true
+		}
+	}
+
+	return "", false
+}
+
+// setHeaders writes the CORS headers for the request if its origin is allowed
+func (c *CORSConfig) setHeaders(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+
+	allowedOrigin, ok := c.allowedOrigin(origin)
+	if !ok {
+		return
+	}
+
+	headers := c.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultAllowedHeaders
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+	w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+
+	if allowedOrigin != "*" {
+		w.Header().Add("Vary", "Origin")
+	}
+
+	if c.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(c.MaxAge))
+	}
+}
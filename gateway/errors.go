@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+const (
+	jsonRPCVersion = "2.0"
+
+	// Status used when the app serving the relay ran out of relays for its session
+	statusAppExhausted = http.StatusTooManyRequests
+)
+
+// relayErrorStatuses maps the relay error codes reported by Pocket nodes to the HTTP status returned to clients
+var relayErrorStatuses = map[provider.RelayErrorCode]int{
+	provider.AppNotFoundError:           http.StatusInternalServerError,
+	provider.DuplicateProofError:        http.StatusInternalServerError,
+	provider.EmptyPayloadDataError:      http.StatusBadRequest,
+	provider.EvidencedSealedError:       statusAppExhausted,
+	provider.HTTPExecutionError:         http.StatusBadGateway,
+	provider.InvalidBlockHeightError:    http.StatusServiceUnavailable,
+	provider.InvalidSessionError:        http.StatusServiceUnavailable,
+	provider.OutOfSyncRequestError:      http.StatusServiceUnavailable,
+	provider.OverServiceError:           statusAppExhausted,
+	provider.RequestHashError:           http.StatusInternalServerError,
+	provider.UnsupportedBlockchainError: http.StatusBadRequest,
+}
+
+// errorToStatus returns the HTTP status and the message sent to the client for a relay error
+func errorToStatus(err error) (int, string) {
+	var relayErr *provider.RelayError
+	if errors.As(err, &relayErr) {
+		status, ok := relayErrorStatuses[relayErr.Code]
+		if !ok {
+			status = http.StatusBadGateway
+		}
+
+		return status, relayErr.Message
+	}
+
+	var sizeErr *provider.ResponseTooLargeError
+
+	switch {
+	case errors.As(err, &sizeErr):
+		return http.StatusBadGateway, sizeErr.Error()
+	case errors.Is(err, ErrNoApps):
+		return http.StatusServiceUnavailable, err.Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, http.StatusText(http.StatusGatewayTimeout)
+	case errors.Is(err, context.Canceled):
+		// Non-standard status used when the client went away, it will not get to read it anyway
+		return 499, "client closed request"
+	default:
+		return http.StatusBadGateway, http.StatusText(http.StatusBadGateway)
+	}
+}
+
+type errorMessage struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+type errorOutput struct {
+	JSONRPC string          `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Error   errorMessage    `json:"error"`
+}
+
+// writeError writes the error as a JSON-RPC error when the request was a JSON-RPC call
+func writeError(w http.ResponseWriter, jsonRPCID json.RawMessage, status int, message string) {
+	output := errorOutput{
+		Error: errorMessage{Message: message},
+	}
+
+	if jsonRPCID != nil {
+		output.JSONRPC = jsonRPCVersion
+		output.ID = jsonRPCID
+		output.Error.Code = provider.JSONRPCInternalError
+
+		if status < http.StatusInternalServerError {
+			output.Error.Code = provider.JSONRPCInvalidRequest
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(output)
+}
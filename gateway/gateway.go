@@ -0,0 +1,345 @@
+// Package gateway is an HTTP server that serves blockchain requests through Pocket relays
+// Underneath uses the packages Provider and Relayer
+package gateway
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relayer"
+)
+
+const (
+	routePrefix = "/v1/"
+
+	defaultMaxRequestBodySize = int64(1 << 20)
+)
+
+var (
+	// ErrNoProvider error when no provider is provided
+	ErrNoProvider = errors.New("no provider provided")
+	// ErrNoRelayer error when no relayer is provided
+	ErrNoRelayer = errors.New("no relayer provided")
+	// ErrNoAppSelector error when no app selector is provided
+	ErrNoAppSelector = errors.New("no app selector provided")
+	// ErrNoApps error when there is no app available to relay
+	ErrNoApps = errors.New("no apps available")
+	// ErrNoSession error when dispatch returns no session
+	ErrNoSession = errors.New("no session dispatched")
+	// ErrNoNodesAvailable error when every node of the session failed to relay
+	ErrNoNodesAvailable = errors.New("no nodes available")
+)
+
+// Provider interface representing provider functions necessary for Gateway Package
+type Provider interface {
+	DispatchWithCtx(ctx context.Context, appPublicKey, chain string, options *provider.DispatchRequestOptions) (*provider.DispatchOutput, error)
+}
+
+// Relayer interface representing relayer functions necessary for Gateway Package
+type Relayer interface {
+	RelayWithCtx(ctx context.Context, input *relayer.Input, options *provider.RelayRequestOptions) (*relayer.Output, error)
+}
+
+// AppSelector interface representing the selection of the app whose AAT is used for a relay
+type AppSelector interface {
+	SelectApp(chain string) (*provider.PocketAAT, error)
+}
+
+// AppReporter interface implemented by AppSelectors that track the outcome of the relays of their apps
+type AppReporter interface {
+	ReportRelay(aat *provider.PocketAAT, session *provider.Session, err error)
+}
+
+// Config represents the configuration of a Gateway
+type Config struct {
+	Provider Provider
+	// Relayer must sign with the client key of the AATs returned by Apps
+	Relayer Relayer
+	Apps    AppSelector
+	// SessionTTL is how long a dispatched session is reused, defaults to 10 minutes
+	SessionTTL time.Duration
+	// Retries is the amount of extra attempts done on another node when a relay fails
+	Retries int
+	// MaxRequestBodySize is the maximum size in bytes of incoming requests, defaults to 1MB
+	MaxRequestBodySize int64
+	RelayOptions       *provider.RelayRequestOptions
+	// CORS enables Cross-Origin Resource Sharing headers when set
+	CORS *CORSConfig
+}
+
+// Gateway is an http.Handler that serves requests to /v1/{chainID} through Pocket relays
+// JSON-RPC requests are sent as the body of /v1/{chainID}, REST requests append the path i.e. /v1/{chainID}/status
+type Gateway struct {
+	relayer            Relayer
+	apps               AppSelector
+	sessions           *sessionCache
+	retries            int
+	maxRequestBodySize int64
+	relayOptions       *provider.RelayRequestOptions
+	cors               *CORSConfig
+}
+
+// NewGateway returns an instance of Gateway from the given config
+func NewGateway(config Config) (*Gateway, error) {
+	if config.Provider == nil {
+		return nil, ErrNoProvider
+	}
+
+	if config.Relayer == nil {
+		return nil, ErrNoRelayer
+	}
+
+	if config.Apps == nil {
+		return nil, ErrNoAppSelector
+	}
+
+	maxRequestBodySize := config.MaxRequestBodySize
+	if maxRequestBodySize <= 0 {
+		maxRequestBodySize = defaultMaxRequestBodySize
+	}
+
+	return &Gateway{
+		relayer:            config.Relayer,
+		apps:               config.Apps,
+		sessions:           newSessionCache(config.Provider, config.SessionTTL),
+		retries:            config.Retries,
+		maxRequestBodySize: maxRequestBodySize,
+		relayOptions:       config.RelayOptions,
+		cors:               config.CORS,
+	}, nil
+}
+
+// relayRequest represents an incoming request translated to relay terms
+type relayRequest struct {
+	chain  string
+	path   string
+	method string
+	data   string
+	// jsonRPCID is set when the request is a single JSON-RPC call, it is used to build error responses
+	jsonRPCID json.RawMessage
+}
+
+// ServeHTTP implements http.Handler
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if g.cors != nil {
+		g.cors.setHeaders(w, r)
+	}
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		writeError(w, nil, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	request, status, err := g.parseRequest(w, r)
+	if err != nil {
+		writeError(w, nil, status, err.Error())
+		return
+	}
+
+	output, err := g.relay(r.Context(), request)
+	if err != nil {
+		status, message := errorToStatus(err)
+		writeError(w, request.jsonRPCID, status, message)
+		return
+	}
+
+	writeRelayOutput(w, request, output.RelayOutput)
+}
+
+func (g *Gateway) parseRequest(w http.ResponseWriter, r *http.Request) (*relayRequest, int, error) {
+	if !strings.HasPrefix(r.URL.Path, routePrefix) {
+		return nil, http.StatusNotFound, errors.New("route not found")
+	}
+
+	chain, path, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, routePrefix), "/")
+	if chain == "" {
+		return nil, http.StatusNotFound, errors.New("chain not provided")
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, g.maxRequestBodySize))
+	if err != nil {
+		return nil, http.StatusRequestEntityTooLarge, errors.New("request body too large")
+	}
+
+	request := &relayRequest{
+		chain:  chain,
+		method: r.Method,
+		data:   string(body),
+	}
+
+	if path != "" {
+		request.path = "/" + path
+
+		if r.URL.RawQuery != "" {
+			request.path += "?" + r.URL.RawQuery
+		}
+
+		return request, http.StatusOK, nil
+	}
+
+	if len(body) == 0 {
+		return nil, http.StatusBadRequest, errors.New("empty request body")
+	}
+
+	var call struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+	}
+
+	if err := json.Unmarshal(body, &call); err == nil && call.JSONRPC != "" {
+		request.jsonRPCID = call.ID
+	}
+
+	return request, http.StatusOK, nil
+}
+
+// relay sends the request through a Pocket node, retrying on other nodes of the session on failure
+func (g *Gateway) relay(ctx context.Context, request *relayRequest) (*relayer.Output, error) {
+	triedNodes := map[string]bool{}
+	lastErr := ErrNoNodesAvailable
+
+	for attempt := 0; attempt <= g.retries; attempt++ {
+		aat, err := g.apps.SelectApp(request.chain)
+		if err != nil {
+			return nil, err
+		}
+
+		session, err := g.sessions.get(ctx, aat.AppPubKey, request.chain)
+		if err != nil {
+			return nil, err
+		}
+
+		node, err := pickNode(session, triedNodes)
+		if errors.Is(err, ErrNoNodesAvailable) && attempt > 0 {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		triedNodes[node.PublicKey] = true
+
+		output, err := g.relayer.RelayWithCtx(ctx, &relayer.Input{
+			Blockchain: request.chain,
+			Data:       request.data,
+			Method:     request.method,
+			Node:       node,
+			Path:       request.path,
+			PocketAAT:  aat,
+			Session:    session,
+		}, g.relayOptions)
+
+		g.reportRelay(aat, session, output, err)
+
+		if err == nil || isNonJSONOutput(output, err) {
+			return output, nil
+		}
+
+		if isSessionError(err) {
+			g.sessions.invalidate(aat.AppPubKey, request.chain)
+		}
+
+		lastErr = err
+
+		if !isRetryable(ctx, err) {
+			break
+		}
+	}
+
+	return nil, lastErr
+}
+
+// reportRelay reports the outcome of a relay to the app selector when it tracks them
+func (g *Gateway) reportRelay(aat *provider.PocketAAT, session *provider.Session, output *relayer.Output, err error) {
+	reporter, ok := g.apps.(AppReporter)
+	if !ok {
+		return
+	}
+
+	if isNonJSONOutput(output, err) {
+		err = nil
+	}
+
+	reporter.ReportRelay(aat, session, err)
+}
+
+// pickNode returns a random session node that was not tried yet
+func pickNode(session *provider.Session, triedNodes map[string]bool) (*provider.Node, error) {
+	candidates := make([]provider.Node, 0, len(session.Nodes))
+
+	for _, node := range session.Nodes {
+		if !triedNodes[node.PublicKey] {
+			candidates = append(candidates, node)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrNoNodesAvailable
+	}
+
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(len(candidates))))
+	if err != nil {
+		return nil, err
+	}
+
+	return &candidates[index.Int64()], nil
+}
+
+// isNonJSONOutput returns if the relay succeeded with a response that is not JSON, which is valid for REST chains
+func isNonJSONOutput(output *relayer.Output, err error) bool {
+	return errors.Is(err, provider.ErrNonJSONResponse) &&
+		output != nil && output.RelayOutput != nil && output.RelayOutput.Response != ""
+}
+
+func isSessionError(err error) bool {
+	return provider.IsErrorCode(provider.InvalidSessionError, err) ||
+		provider.IsErrorCode(provider.OutOfSyncRequestError, err) ||
+		provider.IsErrorCode(provider.InvalidBlockHeightError, err)
+}
+
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var sizeErr *provider.ResponseTooLargeError
+	if errors.As(err, &sizeErr) {
+		return false
+	}
+
+	return !provider.IsErrorCode(provider.EmptyPayloadDataError, err) &&
+		!provider.IsErrorCode(provider.RequestHashError, err) &&
+		!provider.IsErrorCode(provider.UnsupportedBlockchainError, err)
+}
+
+func writeRelayOutput(w http.ResponseWriter, request *relayRequest, output *provider.RelayOutput) {
+	contentType := "application/json"
+	if !json.Valid([]byte(output.Response)) {
+		contentType = "text/plain; charset=utf-8"
+	}
+
+	// JSON-RPC errors travel inside the response body, only REST failures are reported with their status
+	status := http.StatusOK
+	if request.path != "" && output.StatusCode >= http.StatusBadRequest {
+		status = output.StatusCode
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+
+	_, _ = io.WriteString(w, output.Response)
+}
@@ -0,0 +1,229 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relayer"
+	"github.com/stretchr/testify/require"
+)
+
+type providerMock struct {
+	dispatches int
+}
+
+func (p *providerMock) DispatchWithCtx(ctx context.Context, appPublicKey, chain string, options *provider.DispatchRequestOptions) (*provider.DispatchOutput, error) {
+	p.dispatches++
+
+	return &provider.DispatchOutput{
+		BlockHeight: 21,
+		Session: &provider.Session{
+			Header: provider.SessionHeader{AppPublicKey: appPublicKey, Chain: chain, SessionHeight: 21},
+			Nodes:  []provider.Node{{PublicKey: "node1"}, {PublicKey: "node2"}, {PublicKey: "node3"}},
+		},
+	}, nil
+}
+
+type relayerMock struct {
+	inputs []*relayer.Input
+	relay  func(input *relayer.Input) (*relayer.Output, error)
+}
+
+func (r *relayerMock) RelayWithCtx(ctx context.Context, input *relayer.Input, options *provider.RelayRequestOptions) (*relayer.Output, error) {
+	r.inputs = append(r.inputs, input)
+
+	return r.relay(input)
+}
+
+func successfulRelay(response string) func(input *relayer.Input) (*relayer.Output, error) {
+	return func(input *relayer.Input) (*relayer.Output, error) {
+		return &relayer.Output{
+			RelayOutput: &provider.RelayOutput{Response: response, StatusCode: http.StatusOK},
+			Node:        input.Node,
+		}, nil
+	}
+}
+
+func newTestGateway(c *require.Assertions, relayerMock *relayerMock, config Config) (*Gateway, *providerMock) {
+	providerMock := &providerMock{}
+
+	config.Provider = providerMock
+	config.Relayer = relayerMock
+
+	if config.Apps == nil {
+		config.Apps = NewRandomAppSelector(&provider.PocketAAT{AppPubKey: "app"})
+	}
+
+	gateway, err := NewGateway(config)
+	c.NoError(err)
+
+	return gateway, providerMock
+}
+
+func doRequest(gateway http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	gateway.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+	return recorder
+}
+
+func TestNewGateway(t *testing.T) {
+	c := require.New(t)
+
+	_, err := NewGateway(Config{})
+	c.Equal(ErrNoProvider, err)
+
+	_, err = NewGateway(Config{Provider: &providerMock{}})
+	c.Equal(ErrNoRelayer, err)
+
+	_, err = NewGateway(Config{Provider: &providerMock{}, Relayer: &relayerMock{}})
+	c.Equal(ErrNoAppSelector, err)
+}
+
+func TestGateway_ServeHTTPJSONRPC(t *testing.T) {
+	c := require.New(t)
+
+	relayerMock := &relayerMock{relay: successfulRelay(`{"id":1,"jsonrpc":"2.0","result":"0xdd03e4"}`)}
+	gateway, providerMock := newTestGateway(c, relayerMock, Config{})
+
+	body := `{"id":1,"jsonrpc":"2.0","method":"eth_blockNumber","params":[]}`
+
+	response := doRequest(gateway, http.MethodPost, "/v1/0021", body)
+	c.Equal(http.StatusOK, response.Code)
+	c.Equal("application/json", response.Header().Get("Content-Type"))
+	c.Equal(`{"id":1,"jsonrpc":"2.0","result":"0xdd03e4"}`, response.Body.String())
+
+	response = doRequest(gateway, http.MethodPost, "/v1/0021", body)
+	c.Equal(http.StatusOK, response.Code)
+
+	c.Equal(1, providerMock.dispatches)
+	c.Len(relayerMock.inputs, 2)
+	c.Equal("0021", relayerMock.inputs[0].Blockchain)
+	c.Equal(body, relayerMock.inputs[0].Data)
+	c.Equal(http.MethodPost, relayerMock.inputs[0].Method)
+	c.Empty(relayerMock.inputs[0].Path)
+	c.Equal("app", relayerMock.inputs[0].PocketAAT.AppPubKey)
+
+	response = doRequest(gateway, http.MethodPost, "/v1/0021", "")
+	c.Equal(http.StatusBadRequest, response.Code)
+
+	response = doRequest(gateway, http.MethodPost, "/v2/0021", body)
+	c.Equal(http.StatusNotFound, response.Code)
+
+	response = doRequest(gateway, http.MethodPut, "/v1/0021", body)
+	c.Equal(http.StatusMethodNotAllowed, response.Code)
+}
+
+func TestGateway_ServeHTTPREST(t *testing.T) {
+	c := require.New(t)
+
+	relayerMock := &relayerMock{relay: successfulRelay(`{"height":"21"}`)}
+	gateway, _ := newTestGateway(c, relayerMock, Config{})
+
+	response := doRequest(gateway, http.MethodGet, "/v1/0001/v1/query/height?prove=true", "")
+	c.Equal(http.StatusOK, response.Code)
+	c.Equal(`{"height":"21"}`, response.Body.String())
+
+	c.Equal("/v1/query/height?prove=true", relayerMock.inputs[0].Path)
+	c.Equal(http.MethodGet, relayerMock.inputs[0].Method)
+	c.Empty(relayerMock.inputs[0].Data)
+
+	relayerMock.relay = func(input *relayer.Input) (*relayer.Output, error) {
+		return &relayer.Output{RelayOutput: &provider.RelayOutput{Response: "404 page not found", StatusCode: http.StatusNotFound}},
+			provider.ErrNonJSONResponse
+	}
+
+	response = doRequest(gateway, http.MethodGet, "/v1/0001/unknown", "")
+	c.Equal(http.StatusNotFound, response.Code)
+	c.Equal("text/plain; charset=utf-8", response.Header().Get("Content-Type"))
+	c.Equal("404 page not found", response.Body.String())
+}
+
+func TestGateway_ServeHTTPRelayErrors(t *testing.T) {
+	c := require.New(t)
+
+	relayerMock := &relayerMock{}
+	gateway, providerMock := newTestGateway(c, relayerMock, Config{Retries: 2})
+
+	relayerMock.relay = func(input *relayer.Input) (*relayer.Output, error) {
+		if len(relayerMock.inputs) == 1 {
+			return nil, &provider.RelayError{Code: provider.InvalidSessionError, Message: "invalid session"}
+		}
+
+		return successfulRelay(`{"id":1,"jsonrpc":"2.0","result":"0x1"}`)(input)
+	}
+
+	response := doRequest(gateway, http.MethodPost, "/v1/0021", `{"id":1,"jsonrpc":"2.0","method":"eth_chainId"}`)
+	c.Equal(http.StatusOK, response.Code)
+	c.Equal(2, providerMock.dispatches)
+	c.NotEqual(relayerMock.inputs[0].Node.PublicKey, relayerMock.inputs[1].Node.PublicKey)
+
+	relayerMock.inputs = nil
+	relayerMock.relay = func(input *relayer.Input) (*relayer.Output, error) {
+		return nil, &provider.RelayError{Code: provider.EvidencedSealedError, Message: "evidence is sealed"}
+	}
+
+	response = doRequest(gateway, http.MethodPost, "/v1/0021", `{"id":"7","jsonrpc":"2.0","method":"eth_chainId"}`)
+	c.Equal(http.StatusTooManyRequests, response.Code)
+	c.Len(relayerMock.inputs, 3)
+
+	var output errorOutput
+	c.NoError(json.Unmarshal(response.Body.Bytes(), &output))
+	c.Equal("2.0", output.JSONRPC)
+	c.Equal(`"7"`, string(output.ID))
+	c.Equal(provider.JSONRPCInvalidRequest, output.Error.Code)
+	c.Equal("evidence is sealed", output.Error.Message)
+
+	relayerMock.inputs = nil
+	relayerMock.relay = func(input *relayer.Input) (*relayer.Output, error) {
+		return nil, &provider.RelayError{Code: provider.EmptyPayloadDataError, Message: "empty payload"}
+	}
+
+	response = doRequest(gateway, http.MethodGet, "/v1/0001/status", "")
+	c.Equal(http.StatusBadRequest, response.Code)
+	c.Len(relayerMock.inputs, 1)
+	c.JSONEq(`{"error":{"message":"empty payload"}}`, response.Body.String())
+
+	relayerMock.relay = func(input *relayer.Input) (*relayer.Output, error) {
+		return nil, provider.Err5xxOnConnection
+	}
+
+	response = doRequest(gateway, http.MethodGet, "/v1/0001/status", "")
+	c.Equal(http.StatusBadGateway, response.Code)
+}
+
+func TestGateway_ServeHTTPLimitsAndCORS(t *testing.T) {
+	c := require.New(t)
+
+	relayerMock := &relayerMock{relay: successfulRelay(`{"result":true}`)}
+	gateway, _ := newTestGateway(c, relayerMock, Config{
+		MaxRequestBodySize: 10,
+		CORS:               &CORSConfig{AllowedOrigins: []string{"https://pokt.network"}, MaxAge: 60},
+	})
+
+	response := doRequest(gateway, http.MethodPost, "/v1/0021", `{"id":1,"jsonrpc":"2.0"}`)
+	c.Equal(http.StatusRequestEntityTooLarge, response.Code)
+
+	request := httptest.NewRequest(http.MethodOptions, "/v1/0021", nil)
+	request.Header.Set("Origin", "https://pokt.network")
+
+	recorder := httptest.NewRecorder()
+	gateway.ServeHTTP(recorder, request)
+
+	c.Equal(http.StatusNoContent, recorder.Code)
+	c.Equal("https://pokt.network", recorder.Header().Get("Access-Control-Allow-Origin"))
+	c.Equal("Content-Type", recorder.Header().Get("Access-Control-Allow-Headers"))
+	c.Equal("60", recorder.Header().Get("Access-Control-Max-Age"))
+
+	request.Header.Set("Origin", "https://evil.com")
+
+	recorder = httptest.NewRecorder()
+	gateway.ServeHTTP(recorder, request)
+
+	c.Empty(recorder.Header().Get("Access-Control-Allow-Origin"))
+}
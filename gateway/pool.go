@@ -0,0 +1,243 @@
+package gateway
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+// defaultExhaustedTTL is the time an exhausted app is left out when no newer session is seen
+// It is the length of a Pocket session, 4 blocks of around 15 minutes
+const defaultExhaustedTTL = time.Hour
+
+// ErrNoPoolApps error when an app pool is created without apps
+var ErrNoPoolApps = errors.New("no apps provided to pool")
+
+// PoolStrategy represents how an AppPool distributes relays across its apps
+type PoolStrategy int
+
+const (
+	// RoundRobin selects the apps of a chain in turns
+	RoundRobin PoolStrategy = iota
+	// RemainingRelays selects the app of a chain with the most relays left in its session, apps need MaxRelays
+	RemainingRelays
+)
+
+// PoolApp represents an app of an AppPool
+type PoolApp struct {
+	// AAT must be signed for the client key of the Relayer used by the Gateway
+	AAT *provider.PocketAAT
+	// Chains limits the chains the app is selected for, empty means every chain
+	Chains []string
+	// MaxRelays is the relays the app can do per session, as returned by GetApp
+	MaxRelays int64
+}
+
+// AppPoolConfig represents the configuration of an AppPool
+type AppPoolConfig struct {
+	Apps     []PoolApp
+	Strategy PoolStrategy
+	// ExhaustedTTL is how long an exhausted app is left out if no newer session is seen, defaults to 1 hour
+	ExhaustedTTL time.Duration
+}
+
+// appUsage keeps the relays done by an app for a chain in its latest session
+type appUsage struct {
+	sessionHeight   int
+	relays          int64
+	exhausted       bool
+	exhaustedAt     time.Time
+	exhaustedHeight int
+}
+
+// AppPool is an AppSelector that distributes relays across many apps
+// Apps are left out for the rest of their session once nodes report them as exhausted,
+// see EvidencedSealedError and OverServiceError, the Gateway reports relays through ReportRelay
+type AppPool struct {
+	apps         []PoolApp
+	strategy     PoolStrategy
+	exhaustedTTL time.Duration
+	now          func() time.Time
+
+	mu sync.Mutex
+	// usages is keyed by chain and app public key
+	usages map[string]map[string]*appUsage
+	// nextApps is the round robin position of every chain
+	nextApps map[string]int
+	// sessionHeights is the latest session height seen for every chain
+	sessionHeights map[string]int
+}
+
+// NewAppPool returns an instance of AppPool from the given config
+func NewAppPool(config AppPoolConfig) (*AppPool, error) {
+	if len(config.Apps) == 0 {
+		return nil, ErrNoPoolApps
+	}
+
+	exhaustedTTL := config.ExhaustedTTL
+	if exhaustedTTL <= 0 {
+		exhaustedTTL = defaultExhaustedTTL
+	}
+
+	return &AppPool{
+		apps:           config.Apps,
+		strategy:       config.Strategy,
+		exhaustedTTL:   exhaustedTTL,
+		now:            time.Now,
+		usages:         map[string]map[string]*appUsage{},
+		nextApps:       map[string]int{},
+		sessionHeights: map[string]int{},
+	}, nil
+}
+
+// SelectApp returns the AAT of the next available app for the chain
+// Returns ErrNoApps when every app of the chain is exhausted
+func (p *AppPool) SelectApp(chain string) (*provider.PocketAAT, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	available := p.availableApps(chain)
+	if len(available) == 0 {
+		return nil, ErrNoApps
+	}
+
+	if p.strategy == RemainingRelays {
+		return p.mostRemainingRelays(chain, available).AAT, nil
+	}
+
+	index := p.nextApps[chain] % len(available)
+	p.nextApps[chain] = index + 1
+
+	return available[index].AAT, nil
+}
+
+// ReportRelay records the outcome of a relay done with an app of the pool
+// Successful relays count against the app budget, exhaustion errors leave the app out until its session ends
+func (p *AppPool) ReportRelay(aat *provider.PocketAAT, session *provider.Session, err error) {
+	if aat == nil || session == nil {
+		return
+	}
+
+	chain := session.Header.Chain
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sessionHeight := session.Header.SessionHeight
+	if sessionHeight > p.sessionHeights[chain] {
+		p.sessionHeights[chain] = sessionHeight
+	}
+
+	usage := p.usage(chain, aat.AppPubKey)
+
+	if sessionHeight > usage.sessionHeight {
+		usage.sessionHeight = sessionHeight
+		usage.relays = 0
+	}
+
+	switch {
+	case err == nil:
+		usage.relays++
+	case provider.IsExhaustionError(err):
+		usage.exhausted = true
+		usage.exhaustedAt = p.now()
+		usage.exhaustedHeight = sessionHeight
+	}
+}
+
+// Exhausted returns the public keys of the apps currently left out for the chain
+func (p *AppPool) Exhausted(chain string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var exhausted []string
+
+	for _, app := range p.apps {
+		if servesChain(app, chain) && p.isExhausted(chain, app.AAT.AppPubKey) {
+			exhausted = append(exhausted, app.AAT.AppPubKey)
+		}
+	}
+
+	return exhausted
+}
+
+func (p *AppPool) availableApps(chain string) []PoolApp {
+	available := make([]PoolApp, 0, len(p.apps))
+
+	for _, app := range p.apps {
+		if servesChain(app, chain) && !p.isExhausted(chain, app.AAT.AppPubKey) {
+			available = append(available, app)
+		}
+	}
+
+	return available
+}
+
+func (p *AppPool) mostRemainingRelays(chain string, available []PoolApp) PoolApp {
+	selected := available[0]
+	selectedRemaining := p.remainingRelays(chain, selected)
+
+	for _, app := range available[1:] {
+		if remaining := p.remainingRelays(chain, app); remaining > selectedRemaining {
+			selected, selectedRemaining = app, remaining
+		}
+	}
+
+	return selected
+}
+
+// remainingRelays returns the relays the app has left in the latest session of the chain
+func (p *AppPool) remainingRelays(chain string, app PoolApp) int64 {
+	usage := p.usage(chain, app.AAT.AppPubKey)
+	if usage.sessionHeight < p.sessionHeights[chain] {
+		return app.MaxRelays
+	}
+
+	return app.MaxRelays - usage.relays
+}
+
+// isExhausted returns if the app is exhausted, lifting the mark once a newer session is seen or the TTL passed
+func (p *AppPool) isExhausted(chain, appPublicKey string) bool {
+	usage := p.usage(chain, appPublicKey)
+	if !usage.exhausted {
+		return false
+	}
+
+	if p.sessionHeights[chain] > usage.exhaustedHeight || p.now().Sub(usage.exhaustedAt) >= p.exhaustedTTL {
+		usage.exhausted = false
+	}
+
+	return usage.exhausted
+}
+
+func (p *AppPool) usage(chain, appPublicKey string) *appUsage {
+	chainUsages, ok := p.usages[chain]
+	if !ok {
+		chainUsages = map[string]*appUsage{}
+		p.usages[chain] = chainUsages
+	}
+
+	usage, ok := chainUsages[appPublicKey]
+	if !ok {
+		usage = &appUsage{}
+		chainUsages[appPublicKey] = usage
+	}
+
+	return usage
+}
+
+func servesChain(app PoolApp, chain string) bool {
+	if len(app.Chains) == 0 {
+		return true
+	}
+
+	for _, appChain := range app.Chains {
+		if appChain == chain {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,158 @@
+package gateway
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relayer"
+	"github.com/stretchr/testify/require"
+)
+
+func testSession(chain string, sessionHeight int) *provider.Session {
+	return &provider.Session{Header: provider.SessionHeader{Chain: chain, SessionHeight: sessionHeight}}
+}
+
+func TestNewAppPool(t *testing.T) {
+	c := require.New(t)
+
+	_, err := NewAppPool(AppPoolConfig{})
+	c.Equal(ErrNoPoolApps, err)
+}
+
+func TestAppPool_RoundRobin(t *testing.T) {
+	c := require.New(t)
+
+	app1 := &provider.PocketAAT{AppPubKey: "app1"}
+	app2 := &provider.PocketAAT{AppPubKey: "app2"}
+	app3 := &provider.PocketAAT{AppPubKey: "app3"}
+
+	pool, err := NewAppPool(AppPoolConfig{Apps: []PoolApp{
+		{AAT: app1},
+		{AAT: app2, Chains: []string{"0021"}},
+		{AAT: app3, Chains: []string{"0001"}},
+	}})
+	c.NoError(err)
+
+	for _, expected := range []*provider.PocketAAT{app1, app2, app1, app2} {
+		aat, err := pool.SelectApp("0021")
+		c.NoError(err)
+		c.Equal(expected, aat)
+	}
+
+	aat, err := pool.SelectApp("0001")
+	c.NoError(err)
+	c.Equal(app1, aat)
+
+	pool.ReportRelay(app1, testSession("0021", 21), &provider.RelayError{Code: provider.OverServiceError})
+	c.Equal([]string{"app1"}, pool.Exhausted("0021"))
+	c.Empty(pool.Exhausted("0001"))
+
+	for i := 0; i < 2; i++ {
+		aat, err = pool.SelectApp("0021")
+		c.NoError(err)
+		c.Equal(app2, aat)
+	}
+
+	// Other errors don't exhaust the app
+	pool.ReportRelay(app2, testSession("0021", 21), &provider.RelayError{Code: provider.HTTPExecutionError})
+	pool.ReportRelay(app2, testSession("0021", 21), &provider.RelayError{Code: provider.EvidencedSealedError})
+
+	_, err = pool.SelectApp("0021")
+	c.Equal(ErrNoApps, err)
+
+	// A newer session lifts the exhaustion
+	pool.ReportRelay(app3, testSession("0021", 25), nil)
+	c.Empty(pool.Exhausted("0021"))
+
+	aat, err = pool.SelectApp("0021")
+	c.NoError(err)
+	c.NotNil(aat)
+}
+
+func TestAppPool_ExhaustedTTL(t *testing.T) {
+	c := require.New(t)
+
+	app := &provider.PocketAAT{AppPubKey: "app"}
+
+	pool, err := NewAppPool(AppPoolConfig{Apps: []PoolApp{{AAT: app}}, ExhaustedTTL: time.Minute})
+	c.NoError(err)
+
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+
+	pool.ReportRelay(app, testSession("0021", 21), &provider.RelayError{Code: provider.EvidencedSealedError})
+
+	_, err = pool.SelectApp("0021")
+	c.Equal(ErrNoApps, err)
+
+	now = now.Add(time.Minute)
+
+	aat, err := pool.SelectApp("0021")
+	c.NoError(err)
+	c.Equal(app, aat)
+}
+
+func TestAppPool_RemainingRelays(t *testing.T) {
+	c := require.New(t)
+
+	app1 := &provider.PocketAAT{AppPubKey: "app1"}
+	app2 := &provider.PocketAAT{AppPubKey: "app2"}
+
+	pool, err := NewAppPool(AppPoolConfig{
+		Apps:     []PoolApp{{AAT: app1, MaxRelays: 3}, {AAT: app2, MaxRelays: 2}},
+		Strategy: RemainingRelays,
+	})
+	c.NoError(err)
+
+	var selected []string
+
+	for i := 0; i < 5; i++ {
+		aat, err := pool.SelectApp("0021")
+		c.NoError(err)
+
+		selected = append(selected, aat.AppPubKey)
+		pool.ReportRelay(aat, testSession("0021", 21), nil)
+	}
+
+	c.Equal([]string{"app1", "app1", "app2", "app1", "app2"}, selected)
+
+	// Budgets restart with the session
+	pool.ReportRelay(app2, testSession("0021", 25), nil)
+
+	aat, err := pool.SelectApp("0021")
+	c.NoError(err)
+	c.Equal(app1, aat)
+}
+
+func TestGateway_AppPool(t *testing.T) {
+	c := require.New(t)
+
+	pool, err := NewAppPool(AppPoolConfig{Apps: []PoolApp{
+		{AAT: &provider.PocketAAT{AppPubKey: "app1"}},
+		{AAT: &provider.PocketAAT{AppPubKey: "app2"}},
+	}})
+	c.NoError(err)
+
+	relayerMock := &relayerMock{}
+	relayerMock.relay = func(input *relayer.Input) (*relayer.Output, error) {
+		if input.PocketAAT.AppPubKey == "app1" {
+			return nil, &provider.RelayError{Code: provider.OverServiceError, Message: "over service"}
+		}
+
+		return successfulRelay(`{"id":1,"jsonrpc":"2.0","result":"0x1"}`)(input)
+	}
+
+	gateway, _ := newTestGateway(c, relayerMock, Config{Apps: pool, Retries: 1})
+
+	response := doRequest(gateway, http.MethodPost, "/v1/0021", `{"id":1,"jsonrpc":"2.0","method":"eth_chainId"}`)
+	c.Equal(http.StatusOK, response.Code)
+	c.Len(relayerMock.inputs, 2)
+	c.Equal([]string{"app1"}, pool.Exhausted("0021"))
+
+	response = doRequest(gateway, http.MethodPost, "/v1/0021", `{"id":1,"jsonrpc":"2.0","method":"eth_chainId"}`)
+	c.Equal(http.StatusOK, response.Code)
+	c.Len(relayerMock.inputs, 3)
+	c.Equal("app2", relayerMock.inputs[2].PocketAAT.AppPubKey)
+}
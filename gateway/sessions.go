@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+// defaultSessionTTL is the time a dispatched session is reused before dispatching again
+// Pocket sessions last 4 blocks of around 15 minutes, refreshing earlier keeps nodes from rejecting relays
+const defaultSessionTTL = 10 * time.Minute
+
+type sessionKey struct {
+	appPublicKey string
+	chain        string
+}
+
+type cachedSession struct {
+	session   *provider.Session
+	expiresAt time.Time
+}
+
+// sessionCache keeps the dispatched session of every app and chain pair
+type sessionCache struct {
+	provider Provider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	sessions map[sessionKey]*cachedSession
+}
+
+func newSessionCache(provider Provider, ttl time.Duration) *sessionCache {
+	if ttl <= 0 {
+		ttl = defaultSessionTTL
+	}
+
+	return &sessionCache{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		sessions: map[sessionKey]*cachedSession{},
+	}
+}
+
+// get returns the cached session for the app and chain, dispatching a new one if missing or expired
+func (c *sessionCache) get(ctx context.Context, appPublicKey, chain string) (*provider.Session, error) {
+	key := sessionKey{appPublicKey: appPublicKey, chain: chain}
+
+	c.mu.Lock()
+	cached, ok := c.sessions[key]
+	c.mu.Unlock()
+
+	if ok && c.now().Before(cached.expiresAt) {
+		return cached.session, nil
+	}
+
+	output, err := c.provider.DispatchWithCtx(ctx, appPublicKey, chain, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.Session == nil {
+		return nil, ErrNoSession
+	}
+
+	c.mu.Lock()
+	c.sessions[key] = &cachedSession{
+		session:   output.Session,
+		expiresAt: c.now().Add(c.ttl),
+	}
+	c.mu.Unlock()
+
+	return output.Session, nil
+}
+
+// invalidate drops the cached session so the next relay dispatches a new one
+func (c *sessionCache) invalidate(appPublicKey, chain string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.sessions, sessionKey{appPublicKey: appPublicKey, chain: chain})
+}
@@ -0,0 +1,168 @@
+package payout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Status enum that represents the state of a payment in the ledger
+type Status string
+
+const (
+	// Signed the transaction is signed and may have reached the node, it is sent again with the same bytes
+	Signed Status = "signed"
+	// Submitted the node accepted the transaction, it is not known yet if it was included
+	Submitted Status = "submitted"
+	// Confirmed the transaction was included in a block and succeeded
+	Confirmed Status = "confirmed"
+	// Failed the transaction was rejected or failed on chain, the recipient was not paid
+	Failed Status = "failed"
+)
+
+// LedgerEntry represents the state of a payment, the entries of an index replace the previous ones
+type LedgerEntry struct {
+	Index     int    `json:"index"`
+	Recipient string `json:"recipient"`
+	Amount    int64  `json:"amount"`
+	Memo      string `json:"memo,omitempty"`
+	Hash      string `json:"hash"`
+	// RawHexBytes is the signed transaction, kept to send it again without signing a new one
+	RawHexBytes string `json:"raw_hex_bytes"`
+	Fee         int64  `json:"fee"`
+	Status      Status `json:"status"`
+	Height      int    `json:"height,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// matches returns whether the entry was written for the payment
+func (e *LedgerEntry) matches(payment *Payment) bool {
+	return e.Recipient == payment.Recipient && e.Amount == payment.Amount && e.Memo == payment.Memo
+}
+
+// Ledger is an append only file of JSON lines recording every state of the payments of a manifest,
+// so an interrupted run can continue without paying anyone twice
+type Ledger struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries map[int]*LedgerEntry
+}
+
+// OpenLedger returns the ledger at the path, creating it when it does not exist
+// A last line cut by a crash is discarded
+func OpenLedger(path string) (*Ledger, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := &Ledger{file: file, entries: map[int]*LedgerEntry{}}
+
+	if err := ledger.load(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
+func (l *Ledger) load() error {
+	data, err := io.ReadAll(l.file)
+	if err != nil {
+		return err
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	offset := 0
+
+	for i, line := range lines {
+		last := i == len(lines)-1
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var entry LedgerEntry
+
+			if err := json.Unmarshal(line, &entry); err != nil {
+				if !last {
+					return fmt.Errorf("invalid ledger line %d: %w", i+1, err)
+				}
+
+				return l.file.Truncate(int64(offset))
+			}
+
+			l.entries[entry.Index] = &entry
+		}
+
+		offset += len(line) + 1
+	}
+
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		_, err = l.file.Write([]byte("\n"))
+	}
+
+	return err
+}
+
+// Entry returns the last entry of the payment at the index
+func (l *Ledger) Entry(index int) (*LedgerEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[index]
+	if !ok {
+		return nil, false
+	}
+
+	entryCopy := *entry
+
+	return &entryCopy, true
+}
+
+// Entries returns the last entry of every payment ordered by index
+func (l *Ledger) Entries() []*LedgerEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]*LedgerEntry, 0, len(l.entries))
+
+	for _, entry := range l.entries {
+		entryCopy := *entry
+		entries = append(entries, &entryCopy)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Index < entries[j].Index })
+
+	return entries
+}
+
+// record appends the entry and syncs the file before returning
+func (l *Ledger) record(entry *LedgerEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+
+	entryCopy := *entry
+	l.entries[entry.Index] = &entryCopy
+
+	return nil
+}
+
+// Close closes the ledger file
+func (l *Ledger) Close() error {
+	return l.file.Close()
+}
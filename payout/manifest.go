@@ -0,0 +1,133 @@
+package payout
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pokt-foundation/pocket-go/utils"
+)
+
+var (
+	// ErrUnknownManifestFormat error when the manifest file is not CSV nor JSON
+	ErrUnknownManifestFormat = errors.New("unknown manifest format, use a .csv or .json file")
+	// ErrEmptyManifest error when the manifest has no payments
+	ErrEmptyManifest = errors.New("manifest has no payments")
+)
+
+// Payment represents a transfer of a payout manifest, the amount is in upokt
+type Payment struct {
+	Recipient string `json:"recipient"`
+	Amount    int64  `json:"amount"`
+	Memo      string `json:"memo,omitempty"`
+}
+
+// ManifestError error when a payment of a manifest is invalid, Index is the position of the payment
+type ManifestError struct {
+	Index  int
+	Reason string
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *ManifestError) Error() string {
+	return fmt.Sprintf("invalid payment %d: %s", e.Index, e.Reason)
+}
+
+// ReadManifest returns the payments of a CSV or JSON manifest file, chosen by its extension
+func ReadManifest(path string) ([]*Payment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseOrLog(file)
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReadCSV(file)
+	case ".json":
+		return ReadJSON(file)
+	default:
+		return nil, ErrUnknownManifestFormat
+	}
+}
+
+// ReadCSV returns the payments of a CSV manifest with the columns recipient, amount and an optional memo
+// A first row with the column names is skipped
+func ReadCSV(r io.Reader) ([]*Payment, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) > 0 && len(records[0]) > 1 && strings.EqualFold(strings.TrimSpace(records[0][1]), "amount") {
+		records = records[1:]
+	}
+
+	payments := make([]*Payment, 0, len(records))
+
+	for i, record := range records {
+		if len(record) < 2 || len(record) > 3 {
+			return nil, &ManifestError{Index: i, Reason: fmt.Sprintf("expected 2 or 3 columns, got %d", len(record))}
+		}
+
+		amount, err := strconv.ParseInt(strings.TrimSpace(record[1]), 10, 64)
+		if err != nil {
+			return nil, &ManifestError{Index: i, Reason: "amount is not an integer: " + record[1]}
+		}
+
+		payment := &Payment{Recipient: strings.TrimSpace(record[0]), Amount: amount}
+
+		if len(record) == 3 {
+			payment.Memo = record[2]
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return payments, nil
+}
+
+// ReadJSON returns the payments of a JSON manifest, an array of payments
+func ReadJSON(r io.Reader) ([]*Payment, error) {
+	var payments []*Payment
+
+	if err := json.NewDecoder(r).Decode(&payments); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// ValidatePayments returns ManifestError for the first payment with an invalid recipient or amount
+func ValidatePayments(payments []*Payment) error {
+	if len(payments) == 0 {
+		return ErrEmptyManifest
+	}
+
+	for i, payment := range payments {
+		if payment == nil {
+			return &ManifestError{Index: i, Reason: "payment is empty"}
+		}
+
+		if !utils.ValidateAddress(payment.Recipient) {
+			return &ManifestError{Index: i, Reason: "invalid recipient address: " + payment.Recipient}
+		}
+
+		if payment.Amount <= 0 {
+			return &ManifestError{Index: i, Reason: "amount must be greater than zero"}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,367 @@
+// Package payout pays the recipients of a manifest with send transactions, recording them in a ledger
+// so interrupted runs can continue without paying anyone twice
+// Underneath uses the packages Provider and TransactionBuilder
+package payout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+)
+
+var (
+	// ErrNoProvider error when no provider is provided
+	ErrNoProvider = errors.New("no provider provided")
+	// ErrNoTransactionBuilder error when no transaction builder is provided
+	ErrNoTransactionBuilder = errors.New("no transaction builder provided")
+	// ErrNoFromAddress error when no address paying the payments is provided
+	ErrNoFromAddress = errors.New("no from address provided")
+	// ErrNoLedger error when no ledger is provided
+	ErrNoLedger = errors.New("no ledger provided")
+	// ErrLedgerMismatch error when the ledger has entries for other payments than the ones of the manifest
+	ErrLedgerMismatch = errors.New("ledger does not match the manifest")
+)
+
+// InsufficientBalanceError error when the balance can't pay the pending payments and their fees
+type InsufficientBalanceError struct {
+	Required *big.Int
+	Balance  *big.Int
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("balance of %s upokt does not cover the %s upokt of the pending payments and fees", e.Balance, e.Required)
+}
+
+// Provider interface representing provider functions necessary for Payout Package
+type Provider interface {
+	GetBalanceWithCtx(ctx context.Context, address string, options *provider.GetBalanceOptions) (*big.Int, error)
+	GetTransactionWithCtx(ctx context.Context, transactionHash string, options *provider.GetTransactionOptions) (*provider.GetTransactionOutput, error)
+}
+
+// Config represents the config of a Payout
+type Config struct {
+	Provider           Provider
+	TransactionBuilder *transactionbuilder.TransactionBuilder
+	// FromAddress is the address of the signer of the TransactionBuilder
+	FromAddress string
+	// Fee of every transaction, defaults to the TransactionBuilder one
+	Fee int64
+	// Queue configures the submission of the transactions, its OnResult is set by the Payout
+	Queue transactionbuilder.QueueConfig
+	// OnEntry is called with every entry recorded in the ledger
+	OnEntry func(entry *LedgerEntry)
+}
+
+// Payout pays the payments of a manifest recording every transaction in a ledger before sending it
+type Payout struct {
+	provider    Provider
+	txBuilder   *transactionbuilder.TransactionBuilder
+	fromAddress string
+	fee         int64
+	queueConfig transactionbuilder.QueueConfig
+	onEntry     func(entry *LedgerEntry)
+}
+
+// Report represents the outcome of a run, Entries has the last entry of every payment of the manifest
+type Report struct {
+	Confirmed int            `json:"confirmed"`
+	Submitted int            `json:"submitted"`
+	Signed    int            `json:"signed"`
+	Failed    int            `json:"failed"`
+	Entries   []*LedgerEntry `json:"entries"`
+}
+
+// NewPayout returns an instance of Payout from the given config
+func NewPayout(config Config) (*Payout, error) {
+	if config.Provider == nil {
+		return nil, ErrNoProvider
+	}
+
+	if config.TransactionBuilder == nil {
+		return nil, ErrNoTransactionBuilder
+	}
+
+	if config.FromAddress == "" {
+		return nil, ErrNoFromAddress
+	}
+
+	if config.Queue.ChainID == "" {
+		return nil, transactionbuilder.ErrNoChainID
+	}
+
+	return &Payout{
+		provider:    config.Provider,
+		txBuilder:   config.TransactionBuilder,
+		fromAddress: config.FromAddress,
+		fee:         config.Fee,
+		queueConfig: config.Queue,
+		onEntry:     config.OnEntry,
+	}, nil
+}
+
+// Run pays the payments not paid yet according to the ledger
+func (p *Payout) Run(payments []*Payment, ledger *Ledger) (*Report, error) {
+	return p.RunWithCtx(context.Background(), payments, ledger)
+}
+
+// RunWithCtx pays the payments not paid yet according to the ledger
+// Transactions recorded but not confirmed are looked up and sent again with the same bytes, failed payments
+// are signed again, nothing is sent when the balance can't cover all of them
+func (p *Payout) RunWithCtx(ctx context.Context, payments []*Payment, ledger *Ledger) (*Report, error) {
+	if ledger == nil {
+		return nil, ErrNoLedger
+	}
+
+	if err := ValidatePayments(payments); err != nil {
+		return nil, err
+	}
+
+	pending, err := p.reconcile(ctx, payments, ledger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.checkBalance(ctx, pending); err != nil {
+		return nil, err
+	}
+
+	inputs := make([]*provider.SendTransactionInput, len(pending))
+
+	for i, entry := range pending {
+		// Transactions are recorded before sending them, the ones recorded in previous runs already are
+		if recorded, ok := ledger.Entry(entry.Index); !ok || recorded.Hash != entry.Hash {
+			if err := p.record(ledger, entry); err != nil {
+				return nil, err
+			}
+		}
+
+		inputs[i] = &provider.SendTransactionInput{Address: p.fromAddress, RawHexBytes: entry.RawHexBytes}
+	}
+
+	var recordErr error
+
+	queueConfig := p.queueConfig
+	queueConfig.OnResult = func(result *transactionbuilder.QueueResult) {
+		entry := pending[result.Index]
+		updateEntry(entry, result)
+
+		if err := p.record(ledger, entry); err != nil && recordErr == nil {
+			recordErr = err
+		}
+	}
+
+	queue, err := transactionbuilder.NewQueue(p.txBuilder, queueConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	queue.SendWithCtx(ctx, inputs)
+
+	if recordErr != nil {
+		return nil, recordErr
+	}
+
+	return newReport(payments, ledger), nil
+}
+
+// reconcile returns the entries to send, resolving the recorded transactions that were included
+// and signing the payments never sent or failed
+func (p *Payout) reconcile(ctx context.Context, payments []*Payment, ledger *Ledger) ([]*LedgerEntry, error) {
+	for _, entry := range ledger.Entries() {
+		if entry.Index >= len(payments) || !entry.matches(payments[entry.Index]) {
+			return nil, fmt.Errorf("%w: payment %d", ErrLedgerMismatch, entry.Index)
+		}
+	}
+
+	var pending []*LedgerEntry
+
+	for i, payment := range payments {
+		entry, ok := ledger.Entry(i)
+
+		if ok && (entry.Status == Signed || entry.Status == Submitted) {
+			included, err := p.lookup(ctx, entry)
+			if err != nil {
+				return nil, err
+			}
+
+			if !included {
+				pending = append(pending, entry)
+				continue
+			}
+
+			if err := p.record(ledger, entry); err != nil {
+				return nil, err
+			}
+		}
+
+		if ok && entry.Status == Confirmed {
+			continue
+		}
+
+		signedEntry, err := p.sign(ctx, i, payment)
+		if err != nil {
+			return nil, err
+		}
+
+		pending = append(pending, signedEntry)
+	}
+
+	return pending, nil
+}
+
+// lookup updates the entry when its transaction was included in a block
+func (p *Payout) lookup(ctx context.Context, entry *LedgerEntry) (bool, error) {
+	output, err := p.provider.GetTransactionWithCtx(ctx, entry.Hash, nil)
+
+	var rpcErr *provider.RPCError
+	if errors.As(err, &rpcErr) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if output.Transaction == nil {
+		return false, nil
+	}
+
+	entry.Status = Confirmed
+	entry.Height = output.Height
+	entry.Error = ""
+
+	if output.TxResult != nil && output.TxResult.Code != 0 {
+		entry.Status = Failed
+		entry.Error = output.TxResult.Log
+	}
+
+	return true, nil
+}
+
+func (p *Payout) sign(ctx context.Context, index int, payment *Payment) (*LedgerEntry, error) {
+	txMsg, err := transactionbuilder.NewSend(p.fromAddress, payment.Recipient, payment.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := p.txBuilder.CreateTransactionWithCtx(ctx, p.queueConfig.ChainID, txMsg, &transactionbuilder.TransactionOptions{
+		Memo: payment.Memo,
+		Fee:  p.fee,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := transactionbuilder.DecodeTransaction(input.RawHexBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LedgerEntry{
+		Index:       index,
+		Recipient:   payment.Recipient,
+		Amount:      payment.Amount,
+		Memo:        payment.Memo,
+		Hash:        decoded.Hash,
+		RawHexBytes: input.RawHexBytes,
+		Fee:         decoded.Fee,
+		Status:      Signed,
+	}, nil
+}
+
+func (p *Payout) checkBalance(ctx context.Context, pending []*LedgerEntry) error {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	required := new(big.Int)
+
+	for _, entry := range pending {
+		required.Add(required, big.NewInt(entry.Amount+entry.Fee))
+	}
+
+	balance, err := p.provider.GetBalanceWithCtx(ctx, p.fromAddress, nil)
+	if err != nil {
+		return err
+	}
+
+	if balance.Cmp(required) < 0 {
+		return &InsufficientBalanceError{Required: required, Balance: balance}
+	}
+
+	return nil
+}
+
+func (p *Payout) record(ledger *Ledger, entry *LedgerEntry) error {
+	if err := ledger.record(entry); err != nil {
+		return err
+	}
+
+	if p.onEntry != nil {
+		entryCopy := *entry
+		p.onEntry(&entryCopy)
+	}
+
+	return nil
+}
+
+// updateEntry sets the status of the entry from the result of sending its transaction
+// Transactions that may have reached the node stay signed to be looked up and sent again
+func updateEntry(entry *LedgerEntry, result *transactionbuilder.QueueResult) {
+	entry.Error = ""
+
+	var failedErr *transactionbuilder.TransactionFailedError
+	var rejectedErr *transactionbuilder.TransactionRejectedError
+	var timeoutErr *transactionbuilder.TransactionTimeoutError
+
+	switch {
+	case result.Err == nil && result.Transaction != nil:
+		entry.Status = Confirmed
+		entry.Height = result.Transaction.Height
+	case result.Err == nil:
+		entry.Status = Submitted
+	case errors.As(result.Err, &failedErr):
+		entry.Status = Failed
+		entry.Height = failedErr.Height
+		entry.Error = result.Err.Error()
+	case errors.As(result.Err, &rejectedErr):
+		entry.Status = Failed
+		entry.Error = result.Err.Error()
+	case errors.As(result.Err, &timeoutErr):
+		entry.Status = Submitted
+	default:
+		entry.Error = result.Err.Error()
+	}
+}
+
+func newReport(payments []*Payment, ledger *Ledger) *Report {
+	report := &Report{}
+
+	for i := range payments {
+		entry, ok := ledger.Entry(i)
+		if !ok {
+			continue
+		}
+
+		switch entry.Status {
+		case Confirmed:
+			report.Confirmed++
+		case Submitted:
+			report.Submitted++
+		case Signed:
+			report.Signed++
+		case Failed:
+			report.Failed++
+		}
+
+		report.Entries = append(report.Entries, entry)
+	}
+
+	return report
+}
@@ -0,0 +1,228 @@
+package payout
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecipients(t *testing.T, count int) []string {
+	c := require.New(t)
+
+	recipients := make([]string, count)
+
+	for i := range recipients {
+		recipient, err := signer.NewRandomSigner()
+		c.NoError(err)
+
+		recipients[i] = recipient.GetAddress()
+	}
+
+	return recipients
+}
+
+func TestReadManifest(t *testing.T) {
+	c := require.New(t)
+
+	recipients := newRecipients(t, 2)
+
+	payments, err := ReadCSV(strings.NewReader("recipient,amount,memo\n" + recipients[0] + ",100,week 1\n" + recipients[1] + ", 200\n"))
+	c.NoError(err)
+	c.Equal([]*Payment{
+		{Recipient: recipients[0], Amount: 100, Memo: "week 1"},
+		{Recipient: recipients[1], Amount: 200},
+	}, payments)
+	c.NoError(ValidatePayments(payments))
+
+	payments, err = ReadCSV(strings.NewReader(recipients[0] + ",100\n"))
+	c.NoError(err)
+	c.Len(payments, 1)
+
+	_, err = ReadCSV(strings.NewReader(recipients[0] + ",1.5\n"))
+
+	var manifestErr *ManifestError
+	c.ErrorAs(err, &manifestErr)
+	c.Equal(0, manifestErr.Index)
+
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "payouts.json")
+	c.NoError(os.WriteFile(jsonPath, []byte(`[{"recipient":"`+recipients[1]+`","amount":300,"memo":"bonus"}]`), 0o600))
+
+	payments, err = ReadManifest(jsonPath)
+	c.NoError(err)
+	c.Equal([]*Payment{{Recipient: recipients[1], Amount: 300, Memo: "bonus"}}, payments)
+
+	_, err = ReadManifest(filepath.Join(dir, "payouts.txt"))
+	c.Error(err)
+
+	c.NoError(os.WriteFile(filepath.Join(dir, "payouts.txt"), nil, 0o600))
+
+	_, err = ReadManifest(filepath.Join(dir, "payouts.txt"))
+	c.Equal(ErrUnknownManifestFormat, err)
+
+	err = ValidatePayments([]*Payment{{Recipient: recipients[0], Amount: 1}, {Recipient: "not an address", Amount: 1}})
+	c.ErrorAs(err, &manifestErr)
+	c.Equal(1, manifestErr.Index)
+
+	err = ValidatePayments([]*Payment{{Recipient: recipients[0], Amount: 0}})
+	c.ErrorContains(err, "amount must be greater than zero")
+
+	c.Equal(ErrEmptyManifest, ValidatePayments(nil))
+}
+
+func TestLedger_CutLine(t *testing.T) {
+	c := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "payouts.ledger")
+	c.NoError(os.WriteFile(path, []byte(`{"index":0,"hash":"AB","status":"signed"}`+"\n"+`{"index":0,"hash":"AB","sta`), 0o600))
+
+	ledger, err := OpenLedger(path)
+	c.NoError(err)
+
+	entry, ok := ledger.Entry(0)
+	c.True(ok)
+	c.Equal(Signed, entry.Status)
+
+	c.NoError(ledger.record(&LedgerEntry{Index: 0, Hash: "AB", Status: Confirmed}))
+	c.NoError(ledger.Close())
+
+	ledger, err = OpenLedger(path)
+	c.NoError(err)
+	defer ledger.Close()
+
+	entry, ok = ledger.Entry(0)
+	c.True(ok)
+	c.Equal(Confirmed, entry.Status)
+	c.Len(ledger.Entries(), 1)
+}
+
+func TestPayout_Run(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 1000000)
+
+	transport := pockettest.NewFaultTransport(nil)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{Timeout: time.Second, Transport: transport})
+
+	var recorded []*LedgerEntry
+
+	payout, err := NewPayout(Config{
+		Provider:           rpcProvider,
+		TransactionBuilder: transactionbuilder.NewTransactionBuilder(rpcProvider, sender),
+		FromAddress:        sender.GetAddress(),
+		Queue: transactionbuilder.QueueConfig{
+			ChainID:    transactionbuilder.Localnet,
+			MaxRetries: 1,
+			MinBackoff: time.Millisecond,
+		},
+		OnEntry: func(entry *LedgerEntry) {
+			recorded = append(recorded, entry)
+		},
+	})
+	c.NoError(err)
+
+	recipients := newRecipients(t, 3)
+	payments := []*Payment{
+		{Recipient: recipients[0], Amount: 100, Memo: "week 1"},
+		{Recipient: recipients[1], Amount: 200},
+		{Recipient: recipients[2], Amount: 300},
+	}
+
+	ledgerPath := filepath.Join(t.TempDir(), "payouts.ledger")
+
+	ledger, err := OpenLedger(ledgerPath)
+	c.NoError(err)
+
+	// The balance must cover every payment and fee before anything is sent
+	_, err = payout.Run([]*Payment{{Recipient: recipients[0], Amount: 1000000}}, ledger)
+
+	var balanceErr *InsufficientBalanceError
+	c.ErrorAs(err, &balanceErr)
+	c.Equal(int64(1010000), balanceErr.Required.Int64())
+	c.Empty(ledger.Entries())
+
+	// Responses lost after the node got the transactions leave them signed
+	transport.AddFault(pockettest.Fault{Route: provider.QueryTXRoute, Err: pockettest.ErrConnectionRefused})
+	transport.AddFault(pockettest.Fault{Route: provider.ClientRawTXRoute, TruncateAt: 10})
+
+	report, err := payout.Run(payments[:2], ledger)
+	c.NoError(err)
+	c.Equal(2, report.Signed)
+	c.Len(recorded, 4)
+	c.NotEmpty(report.Entries[0].Error)
+
+	firstHash := report.Entries[0].Hash
+
+	transport.Reset()
+	server.Commit()
+	c.NoError(ledger.Close())
+
+	// The next run finds them included and only pays the new payment
+	ledger, err = OpenLedger(ledgerPath)
+	c.NoError(err)
+	defer ledger.Close()
+
+	report, err = payout.Run(payments, ledger)
+	c.NoError(err)
+	c.Equal(2, report.Confirmed)
+	c.Equal(1, report.Submitted)
+	c.Equal(firstHash, report.Entries[0].Hash)
+	c.Equal(2, report.Entries[0].Height)
+	c.Empty(report.Entries[0].Error)
+
+	server.Commit()
+
+	report, err = payout.Run(payments, ledger)
+	c.NoError(err)
+	c.Equal(3, report.Confirmed)
+
+	for i, recipient := range recipients {
+		c.Equal(payments[i].Amount, server.Balance(recipient).Int64())
+	}
+
+	c.Equal(int64(1000000-600-3*10000), server.Balance(sender.GetAddress()).Int64())
+
+	// Failed payments are signed again on the next run
+	server.SetBalance(sender.GetAddress(), 15000)
+
+	extra := append(payments, &Payment{Recipient: recipients[0], Amount: 5000})
+
+	report, err = payout.Run(extra, ledger)
+	c.NoError(err)
+	c.Equal(1, report.Submitted)
+
+	server.SetBalance(sender.GetAddress(), 0)
+	server.Commit()
+
+	report, err = payout.Run(extra, ledger)
+	c.ErrorAs(err, &balanceErr)
+	c.Nil(report)
+
+	entry, ok := ledger.Entry(3)
+	c.True(ok)
+	c.Equal(Failed, entry.Status)
+	c.NotEmpty(entry.Error)
+
+	// Ledgers of other manifests are not mixed
+	_, err = payout.Run(payments[1:], ledger)
+	c.ErrorIs(err, ErrLedgerMismatch)
+
+	_, err = NewPayout(Config{Provider: rpcProvider, TransactionBuilder: transactionbuilder.NewTransactionBuilder(rpcProvider, sender)})
+	c.Equal(ErrNoFromAddress, err)
+}
@@ -0,0 +1,224 @@
+package pockettest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+var (
+	// ErrConnectionReset error of a connection closed by the remote host
+	ErrConnectionReset error = &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	// ErrConnectionRefused error of a host not listening on the port
+	ErrConnectionRefused error = &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	// ErrIOTimeout error of a connection that timed out at the network level
+	ErrIOTimeout error = &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}
+	// ErrTLSHandshake error of a failed TLS handshake
+	ErrTLSHandshake = errors.New("remote error: tls: handshake failure")
+	// ErrNoSuchHost error of a host that can't be resolved
+	ErrNoSuchHost error = &net.DNSError{Err: "no such host", IsNotFound: true}
+)
+
+// Fault represents a failure injected by FaultTransport
+// Err takes precedence over the response fields, faults with no Err, StatusCode nor Body
+// let the request through after the latency and can truncate the real response
+type Fault struct {
+	// Route limits the fault to requests to the route, empty matches every route
+	Route provider.V1RPCRoute
+	// Host limits the fault to requests to the host, with or without port, empty matches every host
+	Host string
+	// Times limits how many requests get the fault, zero means every request
+	Times int
+	// Latency delays the request like a slow node, only a canceled request context cuts it short
+	// Timed out requests wait it too, so a client timeout below it is always reported as such
+	Latency time.Duration
+	// Err fails the request with the error, see ErrConnectionReset and similar
+	Err error
+	// StatusCode answers with the status without sending the request, defaults to 200 when Body is set
+	StatusCode int
+	// Body answers with the body without sending the request, i.e. a non JSON body
+	Body string
+	// TruncateAt cuts the response body after the amount of bytes, reads past it fail with io.ErrUnexpectedEOF
+	TruncateAt int
+}
+
+type activeFault struct {
+	Fault
+	applied int
+}
+
+func (f *activeFault) matches(req *http.Request) bool {
+	if f.Times > 0 && f.applied >= f.Times {
+		return false
+	}
+
+	if f.Route != "" && req.URL.Path != string(f.Route) {
+		return false
+	}
+
+	return f.Host == "" || f.Host == req.URL.Host || f.Host == req.URL.Hostname()
+}
+
+// FaultTransport is an http.RoundTripper that injects faults into the requests of a Provider
+// Set it as RequestConfigOpts.Transport, faults are checked in the order they were added and the first match applies
+type FaultTransport struct {
+	transport http.RoundTripper
+
+	mu     sync.Mutex
+	faults []*activeFault
+}
+
+// NewFaultTransport returns a FaultTransport sending requests with the given transport, defaults to http.DefaultTransport
+func NewFaultTransport(transport http.RoundTripper, faults ...Fault) *FaultTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	t := &FaultTransport{transport: transport}
+
+	for _, fault := range faults {
+		t.AddFault(fault)
+	}
+
+	return t
+}
+
+// AddFault adds a fault to inject
+func (t *FaultTransport) AddFault(fault Fault) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.faults = append(t.faults, &activeFault{Fault: fault})
+}
+
+// Reset removes every fault
+func (t *FaultTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.faults = nil
+}
+
+func (t *FaultTransport) nextFault(req *http.Request) *Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, fault := range t.faults {
+		if fault.matches(req) {
+			fault.applied++
+			return &fault.Fault
+		}
+	}
+
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := t.nextFault(req)
+	if fault == nil {
+		return t.transport.RoundTrip(req)
+	}
+
+	if err := wait(req, fault.Latency); err != nil {
+		return nil, err
+	}
+
+	if fault.Err != nil {
+		return nil, fault.Err
+	}
+
+	if fault.StatusCode != 0 || fault.Body != "" {
+		return fault.response(req), nil
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || fault.TruncateAt <= 0 {
+		return resp, err
+	}
+
+	resp.Body = &truncatedBody{body: resp.Body, remaining: fault.TruncateAt}
+	resp.ContentLength = -1
+
+	return resp, nil
+}
+
+func wait(req *http.Request, latency time.Duration) error {
+	if latency <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+	}
+
+	if errors.Is(req.Context().Err(), context.DeadlineExceeded) {
+		<-timer.C
+	}
+
+	return req.Context().Err()
+}
+
+func (f *Fault) response(req *http.Request) *http.Response {
+	status := f.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	var body io.ReadCloser = io.NopCloser(strings.NewReader(f.Body))
+	if f.TruncateAt > 0 {
+		body = &truncatedBody{body: body, remaining: f.TruncateAt}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          body,
+		ContentLength: -1,
+		Request:       req,
+	}
+}
+
+// truncatedBody returns the first bytes of a body and then fails like a connection dropped mid response
+type truncatedBody struct {
+	body      io.ReadCloser
+	remaining int
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	if len(p) > b.remaining {
+		p = p[:b.remaining]
+	}
+
+	n, err := b.body.Read(p)
+	b.remaining -= n
+
+	return n, err
+}
+
+func (b *truncatedBody) Close() error {
+	return b.body.Close()
+}
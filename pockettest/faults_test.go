@@ -0,0 +1,146 @@
+package pockettest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relayer"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func newRelayEnvironment(t *testing.T) (*Server, *relayer.Relayer, *provider.Provider, *relayer.Input) {
+	c := require.New(t)
+
+	server := NewServer(Config{})
+	t.Cleanup(server.Close)
+
+	server.SetChainBackend("0021", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+
+	app, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	c.NoError(server.AddApp(provider.App{PublicKey: app.GetPublicKey(), Chains: []string{"0021"}, StakedTokens: "10000000"}))
+	c.NoError(server.AddNode(provider.Node{PublicKey: app.GetPublicKey(), Chains: []string{"0021"}}))
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+
+	dispatch, err := rpcProvider.Dispatch(app.GetPublicKey(), "0021", nil)
+	c.NoError(err)
+
+	input := &relayer.Input{
+		Blockchain: "0021",
+		Data:       `{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`,
+		Node:       &dispatch.Session.Nodes[0],
+		PocketAAT:  &provider.PocketAAT{AppPubKey: app.GetPublicKey(), ClientPubKey: app.GetPublicKey()},
+		Session:    dispatch.Session,
+	}
+
+	return server, relayer.NewRelayer(app, rpcProvider), rpcProvider, input
+}
+
+func TestFaultTransport_RelayStatusCodes(t *testing.T) {
+	c := require.New(t)
+
+	_, relayClient, rpcProvider, input := newRelayEnvironment(t)
+
+	tests := []struct {
+		name           string
+		fault          Fault
+		expectedStatus int
+		expectedErr    error
+	}{
+		{name: "connection reset", fault: Fault{Err: ErrConnectionReset}, expectedStatus: http.StatusServiceUnavailable},
+		{name: "connection refused", fault: Fault{Err: ErrConnectionRefused}, expectedStatus: http.StatusBadGateway},
+		{name: "tls failure", fault: Fault{Err: ErrTLSHandshake}, expectedStatus: 525},
+		{name: "io timeout", fault: Fault{Err: ErrIOTimeout}, expectedStatus: http.StatusGatewayTimeout},
+		{name: "no such host", fault: Fault{Err: ErrNoSuchHost}, expectedStatus: http.StatusNotFound},
+		{name: "client timeout", fault: Fault{Latency: 500 * time.Millisecond}, expectedStatus: http.StatusRequestTimeout},
+		// Error statuses of the node itself are not in errorStatusCodesMap and are reported as internal errors
+		{name: "bad gateway", fault: Fault{StatusCode: http.StatusBadGateway}, expectedStatus: http.StatusInternalServerError, expectedErr: provider.Err5xxOnConnection},
+		{name: "rate limited", fault: Fault{StatusCode: http.StatusTooManyRequests}, expectedStatus: http.StatusInternalServerError, expectedErr: provider.Err4xxOnConnection},
+	}
+
+	for _, tt := range tests {
+		rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{
+			Timeout:   100 * time.Millisecond,
+			Transport: NewFaultTransport(nil, tt.fault),
+		})
+
+		output, err := relayClient.RelayWithCtx(context.Background(), input, nil)
+		c.Error(err, tt.name)
+		c.Equal(tt.expectedStatus, output.RelayOutput.StatusCode, tt.name)
+
+		if tt.expectedErr != nil {
+			c.ErrorIs(err, tt.expectedErr, tt.name)
+		}
+	}
+
+	// Deadlines of the request context are not client timeouts, so they are reported as internal errors
+	rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{
+		Timeout:   5 * time.Second,
+		Transport: NewFaultTransport(nil, Fault{Latency: 500 * time.Millisecond}),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	output, err := relayClient.RelayWithCtx(ctx, input, nil)
+	c.ErrorIs(err, context.DeadlineExceeded)
+	c.Equal(http.StatusInternalServerError, output.RelayOutput.StatusCode)
+}
+
+func TestFaultTransport_Bodies(t *testing.T) {
+	c := require.New(t)
+
+	_, relayClient, rpcProvider, input := newRelayEnvironment(t)
+
+	transport := NewFaultTransport(nil, Fault{Route: provider.ClientRelayRoute, Body: "<html>502 Bad Gateway</html>"})
+	rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{Transport: transport})
+
+	_, err := relayClient.RelayWithCtx(context.Background(), input, nil)
+	c.Error(err)
+
+	transport.Reset()
+	transport.AddFault(Fault{Route: provider.ClientRelayRoute, TruncateAt: 10})
+
+	_, err = relayClient.RelayWithCtx(context.Background(), input, nil)
+	c.ErrorContains(err, "unexpected EOF")
+
+	// Faults of other routes don't affect relays
+	transport.Reset()
+	transport.AddFault(Fault{Route: provider.QueryHeightRoute, Err: ErrConnectionReset})
+
+	output, err := relayClient.RelayWithCtx(context.Background(), input, nil)
+	c.NoError(err)
+	c.Equal(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`, output.RelayOutput.Response)
+
+	_, err = rpcProvider.GetBlockHeight()
+	c.ErrorContains(err, "connection reset by peer")
+}
+
+func TestFaultTransport_TimesAndHost(t *testing.T) {
+	c := require.New(t)
+
+	server, _, rpcProvider, _ := newRelayEnvironment(t)
+
+	transport := NewFaultTransport(nil,
+		Fault{Host: "unknown.host", Err: ErrNoSuchHost},
+		Fault{Err: ErrConnectionReset, Times: 2},
+	)
+	rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{Transport: transport})
+
+	for i := 0; i < 2; i++ {
+		_, err := rpcProvider.GetBlockHeight()
+		c.ErrorContains(err, "connection reset by peer")
+	}
+
+	height, err := rpcProvider.GetBlockHeight()
+	c.NoError(err)
+	c.Equal(server.Height(), height)
+}
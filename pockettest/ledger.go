@@ -0,0 +1,165 @@
+package pockettest
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+)
+
+type account struct {
+	address   string
+	publicKey string
+	balance   *big.Int
+}
+
+type block struct {
+	height int
+	time   time.Time
+	txs    []*provider.Transaction
+}
+
+// pendingTx represents a transaction accepted into the mempool
+type pendingTx struct {
+	hash  string
+	bytes []byte
+	tx    authTypes.StdTx
+}
+
+// ledger is the in-memory state of the fake node, it is not safe for concurrent use
+type ledger struct {
+	height   int
+	accounts map[string]*account
+	nodes    map[string]*provider.Node
+	apps     map[string]*provider.App
+	blocks   map[int]*block
+	txs      map[string]*provider.Transaction
+	// txList keeps every mined transaction in the order they were included
+	txList  []*provider.Transaction
+	mempool []*pendingTx
+}
+
+func newLedger(startHeight int, genesisTime time.Time) *ledger {
+	return &ledger{
+		height:   startHeight,
+		accounts: map[string]*account{},
+		nodes:    map[string]*provider.Node{},
+		apps:     map[string]*provider.App{},
+		blocks: map[int]*block{
+			startHeight: {height: startHeight, time: genesisTime},
+		},
+		txs: map[string]*provider.Transaction{},
+	}
+}
+
+// account returns the account of the address, creating it empty if missing
+func (l *ledger) account(address string) *account {
+	address = strings.ToLower(address)
+
+	acc, ok := l.accounts[address]
+	if !ok {
+		acc = &account{address: address, balance: big.NewInt(0)}
+		l.accounts[address] = acc
+	}
+
+	return acc
+}
+
+func (l *ledger) sortedAddresses() []string {
+	addresses := make([]string, 0, len(l.accounts))
+
+	for address := range l.accounts {
+		addresses = append(addresses, address)
+	}
+
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+// transfer moves the amount between accounts, returning false when the sender can't afford it
+func (l *ledger) transfer(from, to string, amount *big.Int) bool {
+	if !l.debit(from, amount) {
+		return false
+	}
+
+	l.credit(to, amount)
+
+	return true
+}
+
+func (l *ledger) debit(address string, amount *big.Int) bool {
+	acc := l.account(address)
+	if acc.balance.Cmp(amount) < 0 {
+		return false
+	}
+
+	acc.balance = new(big.Int).Sub(acc.balance, amount)
+
+	return true
+}
+
+func (l *ledger) credit(address string, amount *big.Int) {
+	acc := l.account(address)
+	acc.balance = new(big.Int).Add(acc.balance, amount)
+}
+
+func (l *ledger) isKnownTx(hash string) bool {
+	if _, ok := l.txs[hash]; ok {
+		return true
+	}
+
+	for _, pending := range l.mempool {
+		if pending.hash == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// commit mines the mempool into a new block, deliver applies every transaction and returns its result
+func (l *ledger) commit(blockTime time.Time, deliver func(pending *pendingTx) *provider.TxResult) {
+	l.height++
+
+	newBlock := &block{height: l.height, time: blockTime}
+	l.blocks[l.height] = newBlock
+
+	for i, pending := range l.mempool {
+		tx := &provider.Transaction{
+			Hash:     pending.hash,
+			Height:   l.height,
+			Index:    i,
+			StdTx:    toStdTx(&pending.tx),
+			Tx:       base64.StdEncoding.EncodeToString(pending.bytes),
+			TxResult: deliver(pending),
+		}
+
+		newBlock.txs = append(newBlock.txs, tx)
+		l.txs[tx.Hash] = tx
+		l.txList = append(l.txList, tx)
+	}
+
+	l.mempool = nil
+}
+
+// txHash returns the hash Tendermint gives to the transaction bytes
+func txHash(txBytes []byte) string {
+	hash := sha256.Sum256(txBytes)
+
+	return strings.ToUpper(hex.EncodeToString(hash[:]))
+}
+
+// blockHash returns a deterministic hash for the block at the height
+func blockHash(chainID string, height int) string {
+	hash := sha256.Sum256([]byte(chainID + "/" + strconv.Itoa(height)))
+
+	return strings.ToUpper(hex.EncodeToString(hash[:]))
+}
@@ -0,0 +1,182 @@
+package pockettest
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	coreTypes "github.com/pokt-network/pocket-core/types"
+	appsType "github.com/pokt-network/pocket-core/x/apps/types"
+	nodesTypes "github.com/pokt-network/pocket-core/x/nodes/types"
+)
+
+// applyMsg applies the effects of a message to the ledger
+// Unstaking entities keep their stake locked, messages of other modules i.e. governance are accepted with no effects
+func (s *Server) applyMsg(signer string, msg coreTypes.Msg) coreTypes.Error {
+	switch msg := msg.(type) {
+	case *nodesTypes.MsgSend:
+		return s.applySend(msg)
+	case *nodesTypes.MsgStake:
+		return s.applyNodeStake(signer, msg)
+	case *nodesTypes.MsgBeginUnstake:
+		return s.applyNodeUnstake(msg.Address.String())
+	case *nodesTypes.MsgUnjail:
+		return s.applyNodeUnjail(msg.ValidatorAddr.String())
+	case *appsType.MsgStake:
+		return s.applyAppStake(signer, msg)
+	case *appsType.MsgBeginUnstake:
+		return s.applyAppUnstake(msg.Address.String())
+	case *appsType.MsgUnjail:
+		return s.applyAppUnjail(msg.AppAddr.String())
+	default:
+		return nil
+	}
+}
+
+func (s *Server) applySend(msg *nodesTypes.MsgSend) coreTypes.Error {
+	if !s.ledger.transfer(msg.FromAddress.String(), msg.ToAddress.String(), msg.Amount.BigInt()) {
+		return coreTypes.ErrInsufficientCoins(fmt.Sprintf("insufficient account funds to send %supokt", msg.Amount))
+	}
+
+	return nil
+}
+
+// stakeDelta returns how much the signer must pay to reach the new stake, Pocket doesn't allow lowering a stake
+func stakeDelta(currentStake string, value *big.Int) (*big.Int, bool) {
+	current, ok := new(big.Int).SetString(currentStake, 10)
+	if !ok {
+		return value, true
+	}
+
+	if value.Cmp(current) < 0 {
+		return nil, false
+	}
+
+	return new(big.Int).Sub(value, current), true
+}
+
+func (s *Server) applyNodeStake(signer string, msg *nodesTypes.MsgStake) coreTypes.Error {
+	value := msg.Value.BigInt()
+
+	minimum, _ := new(big.Int).SetString(getParam(s.params, "pos/StakeMinimum"), 10)
+	if minimum != nil && value.Cmp(minimum) < 0 {
+		return nodesTypes.ErrMinimumStake(nodesTypes.DefaultCodespace)
+	}
+
+	address := coreTypes.Address(msg.PublicKey.Address()).String()
+
+	node, ok := s.ledger.nodes[address]
+	if !ok {
+		node = &provider.Node{Address: address, PublicKey: msg.PublicKey.RawString()}
+	}
+
+	delta, ok := stakeDelta(node.Tokens, value)
+	if !ok {
+		return nodesTypes.ErrMinimumEditStake(nodesTypes.DefaultCodespace)
+	}
+
+	if !s.ledger.debit(signer, delta) {
+		return nodesTypes.ErrNotEnoughCoins(nodesTypes.DefaultCodespace)
+	}
+
+	node.Chains = msg.Chains
+	node.ServiceURL = msg.ServiceUrl
+	node.Tokens = value.String()
+	node.Status = int(provider.Staked)
+
+	if len(msg.Output) > 0 {
+		node.OutputAddress = msg.Output.String()
+	}
+
+	s.ledger.nodes[address] = node
+
+	return nil
+}
+
+func (s *Server) applyNodeUnstake(address string) coreTypes.Error {
+	node, ok := s.ledger.nodes[address]
+	if !ok || node.Status != int(provider.Staked) {
+		return nodesTypes.ErrNoValidatorFound(nodesTypes.DefaultCodespace)
+	}
+
+	node.Status = int(provider.Unstaking)
+	node.UnstakingTime = s.now()
+
+	return nil
+}
+
+func (s *Server) applyNodeUnjail(address string) coreTypes.Error {
+	node, ok := s.ledger.nodes[address]
+	if !ok {
+		return nodesTypes.ErrNoValidatorFound(nodesTypes.DefaultCodespace)
+	}
+
+	if !node.Jailed {
+		return nodesTypes.ErrValidatorNotJailed(nodesTypes.DefaultCodespace)
+	}
+
+	node.Jailed = false
+
+	return nil
+}
+
+func (s *Server) applyAppStake(signer string, msg *appsType.MsgStake) coreTypes.Error {
+	value := msg.Value.BigInt()
+
+	minimum, _ := new(big.Int).SetString(getParam(s.params, "application/ApplicationStakeMinimum"), 10)
+	if minimum != nil && value.Cmp(minimum) < 0 {
+		return appsType.ErrMinimumStake(appsType.DefaultCodespace)
+	}
+
+	address := coreTypes.Address(msg.PubKey.Address()).String()
+
+	app, ok := s.ledger.apps[address]
+	if !ok {
+		app = &provider.App{Address: address, PublicKey: msg.PubKey.RawString()}
+	}
+
+	delta, ok := stakeDelta(app.StakedTokens, value)
+	if !ok {
+		return appsType.ErrMinimumEditStake(appsType.DefaultCodespace)
+	}
+
+	if !s.ledger.debit(signer, delta) {
+		return appsType.ErrNotEnoughCoins(appsType.DefaultCodespace)
+	}
+
+	app.Chains = msg.Chains
+	app.StakedTokens = value.String()
+	app.MaxRelays = s.maxRelays(value).String()
+	app.Status = int(provider.Staked)
+
+	s.ledger.apps[address] = app
+
+	return nil
+}
+
+func (s *Server) applyAppUnstake(address string) coreTypes.Error {
+	app, ok := s.ledger.apps[address]
+	if !ok || app.Status != int(provider.Staked) {
+		return appsType.ErrNoApplicationFound(appsType.DefaultCodespace)
+	}
+
+	app.Status = int(provider.Unstaking)
+	app.UnstakingTime = s.now()
+
+	return nil
+}
+
+func (s *Server) applyAppUnjail(address string) coreTypes.Error {
+	app, ok := s.ledger.apps[address]
+	if !ok {
+		return appsType.ErrNoApplicationFound(appsType.DefaultCodespace)
+	}
+
+	if !app.Jailed {
+		return appsType.ErrApplicationNotJailed(appsType.DefaultCodespace)
+	}
+
+	app.Jailed = false
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package pockettest
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	coreTypes "github.com/pokt-network/pocket-core/types"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+)
+
+const (
+	// DefaultSessionNodeCount is the amount of nodes dispatched per session unless changed with SetParam
+	DefaultSessionNodeCount = 5
+	// DefaultBlocksPerSession is the amount of blocks a session lasts unless changed with SetParam
+	DefaultBlocksPerSession = 4
+)
+
+// DefaultParams returns the params the fake node starts with, modeled after mainnet values
+func DefaultParams() *provider.AllParams {
+	return &provider.AllParams{
+		AppParams: provider.ParamGroup{
+			{Key: "application/MaximumChains", Value: "15"},
+			{Key: "application/MaxApplications", Value: "2295"},
+			{Key: "application/ApplicationStakeMinimum", Value: "1000000"},
+			{Key: "application/AppUnstakingTime", Value: "1814000000000000"},
+			{Key: "application/BaseRelaysPerPOKT", Value: "200000"},
+			{Key: "application/ParticipationRateOn", Value: "false"},
+			{Key: "application/StabilityAdjustment", Value: "0"},
+		},
+		AuthParams: provider.ParamGroup{
+			{Key: "auth/MaxMemoCharacters", Value: "75"},
+			{Key: "auth/TxSigLimit", Value: "8"},
+			{Key: "auth/FeeMultipliers", Value: `{"fee_multiplier":null,"default":"1"}`},
+		},
+		GovParams: provider.ParamGroup{
+			{Key: "gov/daoOwner", Value: ""},
+		},
+		NodeParams: provider.ParamGroup{
+			{Key: "pos/StakeDenom", Value: "upokt"},
+			{Key: "pos/StakeMinimum", Value: "15000000000"},
+			{Key: "pos/MaximumChains", Value: "15"},
+			{Key: "pos/MaxValidators", Value: "1000"},
+			{Key: "pos/BlocksPerSession", Value: strconv.Itoa(DefaultBlocksPerSession)},
+			{Key: "pos/UnstakingTime", Value: "1814000000000000"},
+		},
+		PocketParams: provider.ParamGroup{
+			{Key: "pocketcore/SessionNodeCount", Value: strconv.Itoa(DefaultSessionNodeCount)},
+			{Key: "pocketcore/ClaimExpiration", Value: "24"},
+			{Key: "pocketcore/ReplayAttackBurnMultiplier", Value: "3"},
+		},
+	}
+}
+
+// paramGroup returns the group a param key belongs to by its module prefix
+func paramGroup(params *provider.AllParams, key string) *provider.ParamGroup {
+	module, _, _ := strings.Cut(key, "/")
+
+	switch module {
+	case "application":
+		return &params.AppParams
+	case "auth":
+		return &params.AuthParams
+	case "gov":
+		return &params.GovParams
+	case "pos":
+		return &params.NodeParams
+	default:
+		return &params.PocketParams
+	}
+}
+
+func setParam(params *provider.AllParams, key, value string) {
+	group := paramGroup(params, key)
+
+	for i := range *group {
+		if (*group)[i].Key == key {
+			(*group)[i].Value = value
+			return
+		}
+	}
+
+	*group = append(*group, provider.Param{Key: key, Value: value})
+}
+
+func getParam(params *provider.AllParams, key string) string {
+	value, _ := paramGroup(params, key).Get(key)
+	return value
+}
+
+func getIntParam(params *provider.AllParams, key string, defaultValue int) int {
+	value, err := strconv.Atoi(getParam(params, key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+
+	return value
+}
+
+// feeMultipliers parses the auth/FeeMultipliers param, amino encodes its integers as strings
+func feeMultipliers(params *provider.AllParams) authTypes.FeeMultipliers {
+	var raw struct {
+		FeeMultis []struct {
+			Key        string `json:"key"`
+			Multiplier int64  `json:"multiplier,string"`
+		} `json:"fee_multiplier"`
+		Default int64 `json:"default,string"`
+	}
+
+	multipliers := authTypes.FeeMultipliers{Default: 1}
+
+	if err := json.Unmarshal([]byte(getParam(params, "auth/FeeMultipliers")), &raw); err != nil {
+		return multipliers
+	}
+
+	if raw.Default > 0 {
+		multipliers.Default = raw.Default
+	}
+
+	for _, multiplier := range raw.FeeMultis {
+		multipliers.FeeMultis = append(multipliers.FeeMultis, authTypes.FeeMultiplier{
+			Key:        multiplier.Key,
+			Multiplier: multiplier.Multiplier,
+		})
+	}
+
+	return multipliers
+}
+
+// expectedFee returns the fee the message must pay
+func expectedFee(params *provider.AllParams, msg coreTypes.Msg) coreTypes.Coins {
+	return coreTypes.NewCoins(coreTypes.NewCoin(coreTypes.DefaultStakeDenom, feeMultipliers(params).GetFee(msg)))
+}
@@ -0,0 +1,359 @@
+package pockettest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	appsType "github.com/pokt-network/pocket-core/x/apps/types"
+)
+
+// defaultPerPage is the page size used when the request doesn't set one
+const defaultPerPage = 10000
+
+// heightParams represents the params of queries for a single entity, height is ignored as only the latest state is kept
+type heightParams struct {
+	Address string `json:"address"`
+	Height  int    `json:"height"`
+}
+
+type pageParams struct {
+	Height  int            `json:"height"`
+	Page    int            `json:"page"`
+	PerPage int            `json:"per_page"`
+	Order   provider.Order `json:"order"`
+}
+
+type stakedOpts struct {
+	StakingStatus provider.StakingStatus `json:"staking_status"`
+	JailedStatus  provider.JailedStatus  `json:"jailed_status"`
+	Blockchain    string                 `json:"blockchain"`
+	Page          int                    `json:"page"`
+	PerPage       int                    `json:"per_page"`
+}
+
+type stakedParams struct {
+	Height int        `json:"height"`
+	Opts   stakedOpts `json:"opts"`
+}
+
+// pageBounds returns the slice bounds of the requested page and the amount of pages
+func pageBounds(total, page, perPage int) (int, int, int, int) {
+	if page < 1 {
+		page = 1
+	}
+
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+
+	totalPages := (total + perPage - 1) / perPage
+
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	return start, end, page, totalPages
+}
+
+func (s *Server) handleBalance(w http.ResponseWriter, body []byte) {
+	var params heightParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"balance": s.ledger.account(params.Address).balance})
+}
+
+func (s *Server) accountOutput(acc *account) *provider.GetAccountOutput {
+	output := &provider.GetAccountOutput{
+		Address:   acc.address,
+		PublicKey: acc.publicKey,
+	}
+
+	if acc.balance.Sign() > 0 {
+		output.Coins = append(output.Coins, struct {
+			Amount string `json:"amount"`
+			Denom  string `json:"denom"`
+		}{Amount: acc.balance.String(), Denom: "upokt"})
+	}
+
+	return output
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter, body []byte) {
+	var params heightParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.accountOutput(s.ledger.account(params.Address)))
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, body []byte) {
+	var params pageParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	addresses := s.ledger.sortedAddresses()
+	start, end, page, totalPages := pageBounds(len(addresses), params.Page, params.PerPage)
+
+	output := &provider.GetAccountsOutput{
+		Result:     []*provider.GetAccountOutput{},
+		Page:       page,
+		TotalPages: totalPages,
+	}
+
+	for _, address := range addresses[start:end] {
+		output.Result = append(output.Result, s.accountOutput(s.ledger.accounts[address]))
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (s *Server) handleBlock(w http.ResponseWriter, body []byte) {
+	var params pageParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	height := params.Height
+	if height == 0 {
+		height = s.ledger.height
+	}
+
+	requestedBlock, ok := s.ledger.blocks[height]
+	if !ok {
+		writeRPCError(w, http.StatusBadRequest, "height "+strconv.Itoa(height)+" must be less than or equal to the current blockchain height")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.blockOutput(requestedBlock))
+}
+
+func (s *Server) blockOutput(requestedBlock *block) *provider.GetBlockOutput {
+	output := &provider.GetBlockOutput{}
+
+	output.BlockID.Hash = blockHash(s.chainID, requestedBlock.height)
+	output.Block.Header.ChainID = s.chainID
+	output.Block.Header.Height = strconv.Itoa(requestedBlock.height)
+	output.Block.Header.Time = requestedBlock.time
+	output.Block.Header.NumTxs = strconv.Itoa(len(requestedBlock.txs))
+	output.Block.Header.TotalTxs = strconv.Itoa(len(s.ledger.txList))
+	output.Block.Header.LastBlockID.Hash = blockHash(s.chainID, requestedBlock.height-1)
+	output.Block.Data.Txs = []string{}
+
+	for _, tx := range requestedBlock.txs {
+		output.Block.Data.Txs = append(output.Block.Data.Txs, tx.Tx)
+	}
+
+	return output
+}
+
+type blockTXsParams struct {
+	pageParams
+	Prove bool `json:"prove"`
+}
+
+func (s *Server) handleBlockTXs(w http.ResponseWriter, body []byte) {
+	var params blockTXsParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	height := params.Height
+	if height == 0 {
+		height = s.ledger.height
+	}
+
+	requestedBlock, ok := s.ledger.blocks[height]
+	if !ok {
+		writeRPCError(w, http.StatusBadRequest, "height "+strconv.Itoa(height)+" must be less than or equal to the current blockchain height")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, transactionsPage(requestedBlock.txs, &params.pageParams))
+}
+
+type accountTXsParams struct {
+	pageParams
+	Address  string `json:"address"`
+	Received bool   `json:"received"`
+}
+
+func (s *Server) handleAccountTXs(w http.ResponseWriter, body []byte) {
+	var params accountTXsParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	address := s.ledger.account(params.Address).address
+	txs := []*provider.Transaction{}
+
+	for _, tx := range s.ledger.txList {
+		party := tx.TxResult.Signer
+		if params.Received {
+			party = tx.TxResult.Recipient
+		}
+
+		if party == address {
+			txs = append(txs, tx)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, transactionsPage(txs, &params.pageParams))
+}
+
+// transactionsPage returns the requested page of the transactions, sorted descending unless asked otherwise
+func transactionsPage(txs []*provider.Transaction, params *pageParams) *provider.GetAccountTransactionsOutput {
+	sorted := append([]*provider.Transaction{}, txs...)
+
+	if params.Order != provider.AscendantOrder {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if sorted[i].Height != sorted[j].Height {
+				return sorted[i].Height > sorted[j].Height
+			}
+
+			return sorted[i].Index > sorted[j].Index
+		})
+	}
+
+	start, end, _, _ := pageBounds(len(sorted), params.Page, params.PerPage)
+
+	return &provider.GetAccountTransactionsOutput{
+		PageCount: end - start,
+		TotalTxs:  len(sorted),
+		Txs:       sorted[start:end],
+	}
+}
+
+type txParams struct {
+	Hash  string `json:"hash"`
+	Prove bool   `json:"prove"`
+}
+
+func (s *Server) handleTX(w http.ResponseWriter, body []byte) {
+	var params txParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	tx, ok := s.ledger.txs[strings.ToUpper(params.Hash)]
+	if !ok {
+		writeRPCError(w, http.StatusBadRequest, "tx ("+params.Hash+") not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tx)
+}
+
+func (s *Server) handleNode(w http.ResponseWriter, body []byte) {
+	var params heightParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	node, ok := s.ledger.nodes[strings.ToLower(params.Address)]
+	if !ok {
+		writeRPCError(w, http.StatusBadRequest, "validator not found for "+params.Address)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, node)
+}
+
+func (o *stakedOpts) matches(status int, jailed bool, chains []string) bool {
+	if o.StakingStatus != 0 && int(o.StakingStatus) != status {
+		return false
+	}
+
+	if o.JailedStatus != 0 && (o.JailedStatus == provider.Jailed) != jailed {
+		return false
+	}
+
+	return o.Blockchain == "" || containsString(chains, o.Blockchain)
+}
+
+func (s *Server) handleNodes(w http.ResponseWriter, body []byte) {
+	var params stakedParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	nodes := []*provider.Node{}
+
+	for _, node := range s.ledger.nodes {
+		if params.Opts.matches(node.Status, node.Jailed, node.Chains) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Address < nodes[j].Address })
+
+	start, end, page, totalPages := pageBounds(len(nodes), params.Opts.Page, params.Opts.PerPage)
+
+	writeJSON(w, http.StatusOK, &provider.GetNodesOutput{
+		Result:     nodes[start:end],
+		Page:       page,
+		TotalPages: totalPages,
+	})
+}
+
+func (s *Server) handleApp(w http.ResponseWriter, body []byte) {
+	var params heightParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	app, ok := s.ledger.apps[strings.ToLower(params.Address)]
+	if !ok {
+		writeRPCError(w, http.StatusBadRequest, appsType.ErrNoApplicationFound(appsType.ModuleName).Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, app)
+}
+
+func (s *Server) handleApps(w http.ResponseWriter, body []byte) {
+	var params stakedParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	apps := []*provider.App{}
+
+	for _, app := range s.ledger.apps {
+		if params.Opts.matches(app.Status, app.Jailed, app.Chains) {
+			apps = append(apps, app)
+		}
+	}
+
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Address < apps[j].Address })
+
+	start, end, page, totalPages := pageBounds(len(apps), params.Opts.Page, params.Opts.PerPage)
+
+	writeJSON(w, http.StatusOK, &provider.GetAppsOutput{
+		Result:     apps[start:end],
+		Page:       page,
+		TotalPages: totalPages,
+	})
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,330 @@
+package pockettest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FixturesIndexFile is the file listing the recorded fixtures of a directory
+const FixturesIndexFile = "fixtures.json"
+
+// DefaultIgnoredParams are the params that change on every request and are ignored when replaying
+// Relays get a random entropy and thus a new signature each time
+var DefaultIgnoredParams = []string{"proof.entropy", "proof.signature"}
+
+// Fixture represents a recorded request and response pair
+type Fixture struct {
+	Route  string          `json:"route"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Status int             `json:"status"`
+	// File is the name of the file holding the response body, in the same format as provider/samples
+	File string `json:"file"`
+}
+
+// FixtureNotFoundError is returned when replaying a request that was not recorded
+type FixtureNotFoundError struct {
+	Route  string
+	Params string
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *FixtureNotFoundError) Error() string {
+	return fmt.Sprintf("no fixture recorded for route: %s and params: %s", e.Route, e.Params)
+}
+
+// Recorder is an http.RoundTripper that saves every request and response pair as fixtures
+// Set it as RequestConfigOpts.Transport of a Provider to record its traffic
+type Recorder struct {
+	dir       string
+	transport http.RoundTripper
+
+	mu         sync.Mutex
+	fixtures   []Fixture
+	fileCounts map[string]int
+}
+
+// NewRecorder returns a Recorder that writes its fixtures to dir, overwriting any previous recording
+// Requests are sent with the given transport, defaults to http.DefaultTransport
+func NewRecorder(dir string, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		dir:        dir,
+		transport:  transport,
+		fixtures:   []Fixture{},
+		fileCounts: map[string]int{},
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	params, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := r.save(req.URL.Path, params, resp.StatusCode, body); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Fixtures returns the fixtures recorded so far
+func (r *Recorder) Fixtures() []Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Fixture{}, r.fixtures...)
+}
+
+func (r *Recorder) save(route string, params []byte, status int, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fixture := Fixture{
+		Route:  route,
+		Status: status,
+		File:   r.fileName(route, body),
+	}
+
+	if compacted := compactJSON(params); compacted != nil {
+		fixture.Params = compacted
+	}
+
+	if err := os.WriteFile(filepath.Join(r.dir, fixture.File), indentJSON(body), 0o600); err != nil {
+		return err
+	}
+
+	r.fixtures = append(r.fixtures, fixture)
+
+	index, err := json.MarshalIndent(r.fixtures, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(r.dir, FixturesIndexFile), index, 0o600)
+}
+
+// fileName names the body file after its route like provider/samples, i.e. /v1/query/height is query_height.json
+func (r *Recorder) fileName(route string, body []byte) string {
+	name := strings.ReplaceAll(strings.Trim(strings.TrimPrefix(route, "/v1"), "/"), "/", "_")
+	if name == "" {
+		name = "root"
+	}
+
+	r.fileCounts[name]++
+
+	if count := r.fileCounts[name]; count > 1 {
+		name = fmt.Sprintf("%s_%d", name, count)
+	}
+
+	if !json.Valid(body) {
+		return name + ".txt"
+	}
+
+	return name + ".json"
+}
+
+// Replayer is an http.RoundTripper that serves the fixtures saved by a Recorder
+// Requests are matched by route and params, repeated requests get the recorded responses in order
+// and the last one once those run out. The host is ignored so recordings can be replayed against any URL
+type Replayer struct {
+	dir           string
+	ignoredParams []string
+
+	mu       sync.Mutex
+	fixtures []Fixture
+	served   map[string]int
+}
+
+// NewReplayer returns a Replayer serving the fixtures of dir
+// ignoredParams are dot separated paths of params left out of the matching, defaults to DefaultIgnoredParams
+func NewReplayer(dir string, ignoredParams ...string) (*Replayer, error) {
+	index, err := os.ReadFile(filepath.Join(dir, FixturesIndexFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures []Fixture
+
+	if err := json.Unmarshal(index, &fixtures); err != nil {
+		return nil, err
+	}
+
+	if len(ignoredParams) == 0 {
+		ignoredParams = DefaultIgnoredParams
+	}
+
+	return &Replayer{
+		dir:           dir,
+		ignoredParams: ignoredParams,
+		fixtures:      fixtures,
+		served:        map[string]int{},
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	params, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture, err := r.match(req.URL.Path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := os.ReadFile(filepath.Join(r.dir, fixture.File))
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Status, http.StatusText(fixture.Status)),
+		StatusCode:    fixture.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Replayer) match(route string, params []byte) (*Fixture, error) {
+	normalized := normalizeParams(params, r.ignoredParams)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matches []*Fixture
+
+	for i := range r.fixtures {
+		fixture := &r.fixtures[i]
+		if fixture.Route == route && normalizeParams(fixture.Params, r.ignoredParams) == normalized {
+			matches = append(matches, fixture)
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, &FixtureNotFoundError{Route: route, Params: normalized}
+	}
+
+	key := route + normalized
+	index := r.served[key]
+	r.served[key]++
+
+	if index >= len(matches) {
+		index = len(matches) - 1
+	}
+
+	return matches[index], nil
+}
+
+// readRequestBody returns the request body and restores it so it can be sent
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// normalizeParams returns the params as JSON with sorted keys and without the ignored paths
+func normalizeParams(params []byte, ignoredParams []string) string {
+	if len(bytes.TrimSpace(params)) == 0 {
+		return ""
+	}
+
+	var decoded any
+
+	if err := json.Unmarshal(params, &decoded); err != nil {
+		return string(params)
+	}
+
+	for _, path := range ignoredParams {
+		deletePath(decoded, strings.Split(path, "."))
+	}
+
+	normalized, err := json.Marshal(decoded)
+	if err != nil {
+		return string(params)
+	}
+
+	return string(normalized)
+}
+
+func deletePath(value any, path []string) {
+	object, ok := value.(map[string]any)
+	if !ok || len(path) == 0 {
+		return
+	}
+
+	if len(path) == 1 {
+		delete(object, path[0])
+		return
+	}
+
+	deletePath(object[path[0]], path[1:])
+}
+
+func compactJSON(data []byte) json.RawMessage {
+	var buffer bytes.Buffer
+
+	if err := json.Compact(&buffer, data); err != nil {
+		return nil
+	}
+
+	return buffer.Bytes()
+}
+
+// indentJSON formats JSON bodies like the samples, other bodies are returned untouched
+func indentJSON(data []byte) []byte {
+	var buffer bytes.Buffer
+
+	if err := json.Indent(&buffer, data, "", "    "); err != nil {
+		return data
+	}
+
+	return buffer.Bytes()
+}
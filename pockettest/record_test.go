@@ -0,0 +1,132 @@
+package pockettest
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relayer"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	c := require.New(t)
+
+	dir := t.TempDir()
+
+	server := NewServer(Config{StartHeight: 21})
+
+	account, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(account.GetAddress(), 1000000000)
+
+	recorder, err := NewRecorder(dir, nil)
+	c.NoError(err)
+
+	recordingProvider := provider.NewProvider(server.URL, []string{server.URL})
+	recordingProvider.UpdateRequestConfig(provider.RequestConfigOpts{Transport: recorder})
+
+	height, err := recordingProvider.GetBlockHeight()
+	c.NoError(err)
+	c.Equal(21, height)
+
+	server.SetBalance(account.GetAddress(), 2000000000)
+
+	for _, expected := range []int64{2000000000, 2000000000} {
+		balance, err := recordingProvider.GetBalance(account.GetAddress(), nil)
+		c.NoError(err)
+		c.Equal(expected, balance.Int64())
+	}
+
+	_, err = recordingProvider.GetApp(account.GetAddress(), nil)
+	c.Error(err)
+
+	server.Close()
+
+	c.Len(recorder.Fixtures(), 4)
+
+	// Body files keep the format of provider/samples so they can be used with httpmock too
+	sample, err := os.ReadFile(filepath.Join(dir, "query_height.json"))
+	c.NoError(err)
+	c.JSONEq(`{"height": 21}`, string(sample))
+	c.FileExists(filepath.Join(dir, "query_balance_2.json"))
+
+	replayer, err := NewReplayer(dir)
+	c.NoError(err)
+
+	replayingProvider := provider.NewProvider("https://dummy.com", []string{"https://dummy.com"})
+	replayingProvider.UpdateRequestConfig(provider.RequestConfigOpts{Transport: replayer})
+
+	height, err = replayingProvider.GetBlockHeight()
+	c.NoError(err)
+	c.Equal(21, height)
+
+	balance, err := replayingProvider.GetBalance(account.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal(int64(2000000000), balance.Int64())
+
+	_, err = replayingProvider.GetApp(account.GetAddress(), nil)
+	c.ErrorContains(err, "application does not exist for that address")
+	c.True(provider.IsNotFoundError(err))
+
+	_, err = replayingProvider.GetBalance("a83172b67b5ffbfcb8acb95acc0fd0466a9d4bc4", nil)
+
+	var notFoundErr *FixtureNotFoundError
+	c.ErrorAs(err, &notFoundErr)
+	c.Equal(string(provider.QueryBalanceRoute), notFoundErr.Route)
+}
+
+func TestRecorder_ReplayRelay(t *testing.T) {
+	c := require.New(t)
+
+	dir := t.TempDir()
+
+	server := NewServer(Config{})
+
+	server.SetChainBackend("0021", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+
+	app, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	c.NoError(server.AddApp(provider.App{PublicKey: app.GetPublicKey(), Chains: []string{"0021"}, StakedTokens: "10000000"}))
+	c.NoError(server.AddNode(provider.Node{PublicKey: app.GetPublicKey(), Chains: []string{"0021"}}))
+
+	recorder, err := NewRecorder(dir, nil)
+	c.NoError(err)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{Transport: recorder})
+
+	dispatch, err := rpcProvider.Dispatch(app.GetPublicKey(), "0021", nil)
+	c.NoError(err)
+
+	input := &relayer.Input{
+		Blockchain: "0021",
+		Data:       `{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`,
+		Node:       &dispatch.Session.Nodes[0],
+		PocketAAT:  &provider.PocketAAT{AppPubKey: app.GetPublicKey(), ClientPubKey: app.GetPublicKey()},
+		Session:    dispatch.Session,
+	}
+
+	_, err = relayer.NewRelayer(app, rpcProvider).RelayWithCtx(context.Background(), input, nil)
+	c.NoError(err)
+
+	server.Close()
+
+	replayer, err := NewReplayer(dir)
+	c.NoError(err)
+
+	rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{Transport: replayer})
+
+	// The relay is signed again with a new entropy, which is ignored when matching
+	output, err := relayer.NewRelayer(app, rpcProvider).RelayWithCtx(context.Background(), input, nil)
+	c.NoError(err)
+	c.Equal(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`, output.RelayOutput.Response)
+}
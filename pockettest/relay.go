@@ -0,0 +1,260 @@
+package pockettest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relayer"
+	"github.com/pokt-foundation/pocket-go/utils"
+)
+
+// relayKey identifies the relays served by a node to an app during a session
+type relayKey struct {
+	appPublicKey   string
+	chain          string
+	sessionHeight  int
+	servicerPubKey string
+}
+
+// relayError represents a relay rejected with a Pocket Core error code
+type relayError struct {
+	code    provider.RelayErrorCode
+	message string
+}
+
+type dispatchParams struct {
+	AppPublicKey  string `json:"app_public_key"`
+	Chain         string `json:"chain"`
+	SessionHeight int    `json:"session_height"`
+}
+
+// sessionHeight returns the height of the session the given block belongs to
+func (s *Server) sessionHeight(height int) int {
+	blocksPerSession := getIntParam(s.params, "pos/BlocksPerSession", DefaultBlocksPerSession)
+
+	return height - (height-1)%blocksPerSession
+}
+
+func (s *Server) appByPublicKey(publicKey string) (*provider.App, bool) {
+	address, err := utils.GetAddressFromPublickey(publicKey)
+	if err != nil {
+		return nil, false
+	}
+
+	app, ok := s.ledger.apps[address]
+
+	return app, ok
+}
+
+// session returns the nodes serving the app for the chain at the session height
+// Nodes are picked by hashing them with the session header so sessions are deterministic
+func (s *Server) session(app *provider.App, chain string, sessionHeight int) *provider.Session {
+	header := app.PublicKey + "/" + chain + "/" + strconv.Itoa(sessionHeight)
+
+	candidates := []provider.Node{}
+
+	for _, node := range s.ledger.nodes {
+		if node.Status == int(provider.Staked) && !node.Jailed && containsString(node.Chains, chain) {
+			candidates = append(candidates, *node)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return sessionHash(header, candidates[i].PublicKey) < sessionHash(header, candidates[j].PublicKey)
+	})
+
+	sessionNodeCount := getIntParam(s.params, "pocketcore/SessionNodeCount", DefaultSessionNodeCount)
+	if len(candidates) > sessionNodeCount {
+		candidates = candidates[:sessionNodeCount]
+	}
+
+	return &provider.Session{
+		Header: provider.SessionHeader{
+			AppPublicKey:  app.PublicKey,
+			Chain:         chain,
+			SessionHeight: sessionHeight,
+		},
+		Key:   sessionHash(header, ""),
+		Nodes: candidates,
+	}
+}
+
+func sessionHash(header, publicKey string) string {
+	hash := sha256.Sum256([]byte(header + "/" + publicKey))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func (s *Server) handleDispatch(w http.ResponseWriter, body []byte) {
+	var params dispatchParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	app, ok := s.appByPublicKey(params.AppPublicKey)
+	if !ok || app.Status != int(provider.Staked) {
+		writeRPCError(w, http.StatusBadRequest, "application not found for "+params.AppPublicKey)
+		return
+	}
+
+	if !containsString(app.Chains, params.Chain) {
+		writeRPCError(w, http.StatusBadRequest, "the blockchain "+params.Chain+" is not staked for the application")
+		return
+	}
+
+	height := s.ledger.height
+	if params.SessionHeight > 0 {
+		height = params.SessionHeight
+	}
+
+	writeJSON(w, http.StatusOK, &provider.DispatchOutput{
+		BlockHeight: s.ledger.height,
+		Session:     s.session(app, params.Chain, s.sessionHeight(height)),
+	})
+}
+
+func (s *Server) handleRelay(w http.ResponseWriter, body []byte) {
+	var input provider.RelayInput
+	if !decodeParams(w, body, &input) {
+		return
+	}
+
+	if relayErr := validateRelayInput(&input); relayErr != nil {
+		writeRelayError(w, relayErr)
+		return
+	}
+
+	backend, key, relayErr := s.validateRelaySession(input.Proof)
+	if relayErr != nil {
+		writeRelayError(w, relayErr)
+		return
+	}
+
+	response := serveRelay(backend, input.Payload)
+	s.relays[key]++
+
+	writeJSON(w, http.StatusOK, &provider.RelayOutput{
+		Response:  response,
+		Signature: responseSignature(input.Proof.ServicerPubKey, response),
+	})
+}
+
+// validateRelayInput checks the relay is complete and its request hash matches the payload
+func validateRelayInput(input *provider.RelayInput) *relayError {
+	if input.Payload == nil || (input.Payload.Data == "" && input.Payload.Path == "") {
+		return &relayError{code: provider.EmptyPayloadDataError, message: "the payload data of the relay request is empty"}
+	}
+
+	if input.Proof == nil || input.Proof.AAT == nil {
+		return &relayError{code: provider.InvalidSessionError, message: "the relay request has no proof"}
+	}
+
+	requestHash, err := relayer.HashRequest(&relayer.RequestHash{Payload: input.Payload, Meta: input.Meta})
+	if err != nil || requestHash != input.Proof.RequestHash {
+		return &relayError{code: provider.RequestHashError, message: "the request hash does not match the payload"}
+	}
+
+	return nil
+}
+
+// validateRelaySession checks the servicer is in the current session of the app and can still serve it
+func (s *Server) validateRelaySession(proof *provider.RelayProof) (http.Handler, relayKey, *relayError) {
+	key := relayKey{
+		appPublicKey:   proof.AAT.AppPubKey,
+		chain:          proof.Blockchain,
+		sessionHeight:  proof.SessionBlockHeight,
+		servicerPubKey: proof.ServicerPubKey,
+	}
+
+	backend, ok := s.backends[proof.Blockchain]
+	if !ok {
+		return nil, key, &relayError{code: provider.UnsupportedBlockchainError, message: "the blockchain " + proof.Blockchain + " is not supported by this node"}
+	}
+
+	app, ok := s.appByPublicKey(proof.AAT.AppPubKey)
+	if !ok || app.Status != int(provider.Staked) {
+		return nil, key, &relayError{code: provider.AppNotFoundError, message: "the application was not found"}
+	}
+
+	if proof.SessionBlockHeight != s.sessionHeight(s.ledger.height) {
+		return nil, key, &relayError{code: provider.InvalidBlockHeightError, message: "the block height passed is invalid"}
+	}
+
+	session := s.session(app, proof.Blockchain, proof.SessionBlockHeight)
+	if !isServicerInSession(session, proof.ServicerPubKey) {
+		return nil, key, &relayError{code: provider.InvalidSessionError, message: "the servicer is not in the session"}
+	}
+
+	if s.relays[key] >= s.maxRelaysPerNode(app) {
+		return nil, key, &relayError{code: provider.OverServiceError, message: "the max number of relays serviced for this node is exceeded"}
+	}
+
+	return backend, key, nil
+}
+
+// maxRelaysPerNode returns how many relays each session node can serve to the app, Pocket splits them evenly
+func (s *Server) maxRelaysPerNode(app *provider.App) int {
+	allowance, err := relayer.NodeAllowance(app, getIntParam(s.params, "pocketcore/SessionNodeCount", DefaultSessionNodeCount))
+	if err != nil {
+		return int(^uint(0) >> 1)
+	}
+
+	return int(allowance)
+}
+
+func isServicerInSession(session *provider.Session, servicerPubKey string) bool {
+	for _, node := range session.Nodes {
+		if node.PublicKey == servicerPubKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+// serveRelay executes the payload against the chain backend and returns its response body
+func serveRelay(backend http.Handler, payload *provider.RelayPayload) string {
+	method := payload.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	path := payload.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	request := httptest.NewRequest(method, path, strings.NewReader(payload.Data))
+	request.Header.Set("Content-Type", "application/json")
+
+	for key, value := range payload.Headers {
+		request.Header.Set(key, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	backend.ServeHTTP(recorder, request)
+
+	return recorder.Body.String()
+}
+
+// responseSignature returns a deterministic stand-in for the servicer signature, the fake node holds no node keys
+func responseSignature(servicerPubKey, response string) string {
+	hash := sha256.Sum256([]byte(servicerPubKey + response))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func writeRelayError(w http.ResponseWriter, relayErr *relayError) {
+	output := &provider.RelayErrorOutput{}
+	output.Error.Code = relayErr.code
+	output.Error.Codespace = "pocketcore"
+	output.Error.Message = relayErr.message
+
+	writeJSON(w, http.StatusBadRequest, output)
+}
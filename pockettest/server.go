@@ -0,0 +1,308 @@
+// Package pockettest provides an in-process fake Pocket node and RPC traffic recording for integration tests
+// It serves the V1 RPC routes from an in-memory ledger that reacts to signed transactions and relays
+package pockettest
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/utils"
+)
+
+const (
+	defaultChainID     = "localnet"
+	defaultStartHeight = 1
+)
+
+// Config represents the configuration of a fake Pocket node
+type Config struct {
+	// ChainID is the chain ID transactions must be signed for, defaults to localnet
+	ChainID string
+	// StartHeight is the height of the genesis block, defaults to 1
+	StartHeight int
+	// AutoCommit mines a block on every accepted transaction instead of waiting for Commit
+	AutoCommit bool
+	// Params overrides the params returned by allparams, defaults to DefaultParams
+	Params *provider.AllParams
+	// Now returns the time of new blocks, defaults to time.Now
+	Now func() time.Time
+}
+
+// Server is a fake Pocket node listening on a local address
+// Transactions sent to it stay in the mempool until Commit mines them into a block
+type Server struct {
+	*httptest.Server
+
+	chainID    string
+	autoCommit bool
+	now        func() time.Time
+
+	mu       sync.Mutex
+	ledger   *ledger
+	params   *provider.AllParams
+	backends map[string]http.Handler
+	relays   map[relayKey]int
+}
+
+type routeHandler func(s *Server, w http.ResponseWriter, body []byte)
+
+var routes = map[provider.V1RPCRoute]routeHandler{
+	provider.ClientDispatchRoute:       (*Server).handleDispatch,
+	provider.ClientRawTXRoute:          (*Server).handleRawTX,
+	provider.ClientRelayRoute:          (*Server).handleRelay,
+	provider.QueryAccountRoute:         (*Server).handleAccount,
+	provider.QueryAccountsRoute:        (*Server).handleAccounts,
+	provider.QueryAccountTXsRoute:      (*Server).handleAccountTXs,
+	provider.QueryAllParamsRoute:       (*Server).handleAllParams,
+	provider.QueryAppRoute:             (*Server).handleApp,
+	provider.QueryAppsRoute:            (*Server).handleApps,
+	provider.QueryBalanceRoute:         (*Server).handleBalance,
+	provider.QueryBlockRoute:           (*Server).handleBlock,
+	provider.QueryBlockTXsRoute:        (*Server).handleBlockTXs,
+	provider.QueryHeightRoute:          (*Server).handleHeight,
+	provider.QueryNodeRoute:            (*Server).handleNode,
+	provider.QueryNodesRoute:           (*Server).handleNodes,
+	provider.QuerySupportedChainsRoute: (*Server).handleSupportedChains,
+	provider.QueryTXRoute:              (*Server).handleTX,
+}
+
+// NewServer starts and returns a fake Pocket node, callers must Close it when done
+func NewServer(config Config) *Server {
+	s := &Server{
+		chainID:    config.ChainID,
+		autoCommit: config.AutoCommit,
+		now:        config.Now,
+		params:     config.Params,
+		backends:   map[string]http.Handler{},
+		relays:     map[relayKey]int{},
+	}
+
+	if s.chainID == "" {
+		s.chainID = defaultChainID
+	}
+
+	if s.now == nil {
+		s.now = time.Now
+	}
+
+	if s.params == nil {
+		s.params = DefaultParams()
+	}
+
+	startHeight := config.StartHeight
+	if startHeight <= 0 {
+		startHeight = defaultStartHeight
+	}
+
+	s.ledger = newLedger(startHeight, s.now())
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler, ok := routes[provider.V1RPCRoute(r.URL.Path)]
+	if !ok {
+		writeRPCError(w, http.StatusNotFound, "route not found")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	handler(s, w, body)
+}
+
+// Height returns the height of the latest block
+func (s *Server) Height() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ledger.height
+}
+
+// Commit mines the transactions in the mempool into a new block and returns its height
+func (s *Server) Commit() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.commit()
+}
+
+// AdvanceHeight commits the given amount of blocks and returns the new height
+func (s *Server) AdvanceHeight(blocks int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < blocks; i++ {
+		s.commit()
+	}
+
+	return s.ledger.height
+}
+
+// SetBalance sets the upokt balance of the given address
+func (s *Server) SetBalance(address string, amount int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ledger.account(address).balance = big.NewInt(amount)
+}
+
+// Balance returns the upokt balance of the given address
+func (s *Server) Balance(address string) *big.Int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return new(big.Int).Set(s.ledger.account(address).balance)
+}
+
+// AddNode adds a staked node, the address is derived from the public key when missing
+// and the service URL defaults to the server itself so its relays are served by this fake node
+func (s *Server) AddNode(node provider.Node) error {
+	if node.Address == "" {
+		address, err := utils.GetAddressFromPublickey(node.PublicKey)
+		if err != nil {
+			return err
+		}
+
+		node.Address = address
+	}
+
+	node.Address = strings.ToLower(node.Address)
+
+	if node.ServiceURL == "" {
+		node.ServiceURL = s.URL
+	}
+
+	if node.Status == 0 {
+		node.Status = int(provider.Staked)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ledger.account(node.Address).publicKey = node.PublicKey
+	s.ledger.nodes[node.Address] = &node
+
+	return nil
+}
+
+// AddApp adds a staked app, the address is derived from the public key when missing
+// and the max relays are calculated from the staked tokens like Pocket does
+func (s *Server) AddApp(app provider.App) error {
+	if app.Address == "" {
+		address, err := utils.GetAddressFromPublickey(app.PublicKey)
+		if err != nil {
+			return err
+		}
+
+		app.Address = address
+	}
+
+	app.Address = strings.ToLower(app.Address)
+
+	if app.Status == 0 {
+		app.Status = int(provider.Staked)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if app.MaxRelays == "" {
+		stakedTokens, _ := new(big.Int).SetString(app.StakedTokens, 10)
+		app.MaxRelays = s.maxRelays(stakedTokens).String()
+	}
+
+	s.ledger.account(app.Address).publicKey = app.PublicKey
+	s.ledger.apps[app.Address] = &app
+
+	return nil
+}
+
+// SetChainBackend sets the handler that serves the relays of a blockchain and marks it as supported
+func (s *Server) SetChainBackend(chain string, backend http.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.backends[chain] = backend
+}
+
+// SetParam sets the value of a param, the key prefix selects its group i.e. pos/BlocksPerSession
+func (s *Server) SetParam(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setParam(s.params, key, value)
+}
+
+func (s *Server) commit() int {
+	s.ledger.commit(s.now(), s.deliverTx)
+
+	return s.ledger.height
+}
+
+func (s *Server) supportedChains() []string {
+	chains := make([]string, 0, len(s.backends))
+
+	for chain := range s.backends {
+		chains = append(chains, chain)
+	}
+
+	sort.Strings(chains)
+
+	return chains
+}
+
+func (s *Server) handleHeight(w http.ResponseWriter, _ []byte) {
+	writeJSON(w, http.StatusOK, map[string]int{"height": s.ledger.height})
+}
+
+func (s *Server) handleAllParams(w http.ResponseWriter, _ []byte) {
+	writeJSON(w, http.StatusOK, s.params)
+}
+
+func (s *Server) handleSupportedChains(w http.ResponseWriter, _ []byte) {
+	writeJSON(w, http.StatusOK, s.supportedChains())
+}
+
+func writeJSON(w http.ResponseWriter, status int, output any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(output)
+}
+
+// writeRPCError writes an error with the same shape as Pocket RPC errors
+func writeRPCError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, &provider.RPCError{Code: status, Message: message})
+}
+
+// decodeParams decodes the request params, writing the error response when they are invalid
+func decodeParams(w http.ResponseWriter, body []byte, params any) bool {
+	if len(body) == 0 {
+		return true
+	}
+
+	if err := json.Unmarshal(body, params); err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
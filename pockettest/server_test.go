@@ -0,0 +1,255 @@
+package pockettest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relayer"
+	"github.com/pokt-foundation/pocket-go/signer"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_SendTransaction(t *testing.T) {
+	c := require.New(t)
+
+	server := NewServer(Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	receiver, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 100000)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	builder := transactionbuilder.NewTransactionBuilder(rpcProvider, sender)
+
+	msg, err := transactionbuilder.NewSend(sender.GetAddress(), receiver.GetAddress(), 50000)
+	c.NoError(err)
+
+	output, err := builder.Submit(transactionbuilder.Localnet, msg, nil)
+	c.NoError(err)
+	c.NotEmpty(output.Txhash)
+
+	// Transactions are only applied once mined
+	balance, err := rpcProvider.GetBalance(receiver.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal(int64(0), balance.Int64())
+
+	c.Equal(2, server.Commit())
+
+	tx, err := rpcProvider.GetTransaction(output.Txhash, nil)
+	c.NoError(err)
+	c.Equal(2, tx.Height)
+	c.Zero(tx.TxResult.Code)
+	c.Equal("send", tx.TxResult.MessageType)
+	c.Equal(sender.GetAddress(), tx.TxResult.Signer)
+	c.Equal(receiver.GetAddress(), tx.TxResult.Recipient)
+	c.Equal("pos/Send", tx.StdTx.Msg.Type)
+
+	balance, err = rpcProvider.GetBalance(receiver.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal(int64(50000), balance.Int64())
+
+	balance, err = rpcProvider.GetBalance(sender.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal(int64(40000), balance.Int64())
+
+	received, err := rpcProvider.GetAccountTransactions(receiver.GetAddress(), &provider.GetAccountTransactionsOptions{Received: true})
+	c.NoError(err)
+	c.Equal(1, received.TotalTxs)
+	c.Equal(output.Txhash, received.Txs[0].Hash)
+
+	blockTxs, err := rpcProvider.GetBlockTransactions(&provider.GetBlockTransactionsOptions{Height: 2})
+	c.NoError(err)
+	c.Len(blockTxs.Txs, 1)
+
+	block, err := rpcProvider.GetBlock(2)
+	c.NoError(err)
+	c.Equal("1", block.Block.Header.NumTxs)
+}
+
+func TestServer_RejectedTransaction(t *testing.T) {
+	c := require.New(t)
+
+	server := NewServer(Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	builder := transactionbuilder.NewTransactionBuilder(rpcProvider, sender)
+
+	msg, err := transactionbuilder.NewSend(sender.GetAddress(), sender.GetAddress(), 1)
+	c.NoError(err)
+
+	var rejectedErr *transactionbuilder.TransactionRejectedError
+
+	// Empty account can't pay the fee
+	_, err = builder.Submit(transactionbuilder.Localnet, msg, nil)
+	c.ErrorAs(err, &rejectedErr)
+	c.Contains(rejectedErr.Log, "does not have enough coins")
+	c.ErrorIs(err, transactionbuilder.ErrInsufficientFunds)
+
+	server.SetBalance(sender.GetAddress(), 100000)
+
+	// Signatures for another chain are rejected
+	_, err = builder.Submit(transactionbuilder.Mainnet, msg, nil)
+	c.ErrorAs(err, &rejectedErr)
+	c.Contains(rejectedErr.Log, "signature verification failed")
+	c.ErrorIs(err, transactionbuilder.ErrInvalidSignature)
+
+	_, err = builder.Submit(transactionbuilder.Localnet, msg, &transactionbuilder.TransactionOptions{Fee: 1})
+	c.ErrorAs(err, &rejectedErr)
+	c.Contains(rejectedErr.Log, "fee for the transaction was insufficient")
+	c.ErrorIs(err, transactionbuilder.ErrInsufficientFee)
+
+	server.Commit()
+
+	txs, err := rpcProvider.GetAccountTransactions(sender.GetAddress(), nil)
+	c.NoError(err)
+	c.Zero(txs.TotalTxs)
+}
+
+func TestServer_StakeApp(t *testing.T) {
+	c := require.New(t)
+
+	server := NewServer(Config{AutoCommit: true})
+	defer server.Close()
+
+	app, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(app.GetAddress(), 20000000)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	builder := transactionbuilder.NewTransactionBuilder(rpcProvider, app)
+
+	msg, err := transactionbuilder.NewStakeApp(app.GetPublicKey(), []string{"0021"}, 10000000)
+	c.NoError(err)
+
+	_, err = builder.Submit(transactionbuilder.Localnet, msg, nil)
+	c.NoError(err)
+
+	stakedApp, err := rpcProvider.GetApp(app.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal("10000000", stakedApp.StakedTokens)
+	c.Equal("20000", stakedApp.MaxRelays)
+	c.Equal(int(provider.Staked), stakedApp.Status)
+
+	apps, err := rpcProvider.GetApps(&provider.GetAppsOptions{BlockChain: "0021"})
+	c.NoError(err)
+	c.Len(apps.Result, 1)
+
+	balance, err := rpcProvider.GetBalance(app.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal(int64(9990000), balance.Int64())
+
+	msg, err = transactionbuilder.NewUnstakeApp(app.GetAddress())
+	c.NoError(err)
+
+	output, err := builder.Submit(transactionbuilder.Localnet, msg, nil)
+	c.NoError(err)
+
+	tx, err := rpcProvider.GetTransaction(output.Txhash, nil)
+	c.NoError(err)
+	c.Zero(tx.TxResult.Code)
+
+	stakedApp, err = rpcProvider.GetApp(app.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal(int(provider.Unstaking), stakedApp.Status)
+}
+
+func TestServer_Relay(t *testing.T) {
+	c := require.New(t)
+
+	server := NewServer(Config{})
+	defer server.Close()
+
+	server.SetChainBackend("0021", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		c.JSONEq(`{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`, string(body))
+
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+
+	app, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	c.NoError(server.AddApp(provider.App{
+		PublicKey:    app.GetPublicKey(),
+		Chains:       []string{"0021"},
+		StakedTokens: "10000000",
+		MaxRelays:    "10",
+	}))
+
+	for i := 0; i < 3; i++ {
+		node, err := signer.NewRandomSigner()
+		c.NoError(err)
+
+		c.NoError(server.AddNode(provider.Node{PublicKey: node.GetPublicKey(), Chains: []string{"0021"}}))
+	}
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+
+	dispatch, err := rpcProvider.Dispatch(app.GetPublicKey(), "0021", nil)
+	c.NoError(err)
+	c.Len(dispatch.Session.Nodes, 3)
+	c.Equal(1, dispatch.Session.Header.SessionHeight)
+
+	input := &relayer.Input{
+		Blockchain: "0021",
+		Data:       `{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`,
+		Node:       &dispatch.Session.Nodes[0],
+		PocketAAT: &provider.PocketAAT{
+			Version:      "0.0.1",
+			AppPubKey:    app.GetPublicKey(),
+			ClientPubKey: app.GetPublicKey(),
+		},
+		Session: dispatch.Session,
+	}
+
+	relayClient := relayer.NewRelayer(app, rpcProvider)
+
+	// Each node can serve MaxRelays divided by the session node count
+	for i := 0; i < 2; i++ {
+		output, err := relayClient.RelayWithCtx(context.Background(), input, nil)
+		c.NoError(err)
+		c.Equal(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`, output.RelayOutput.Response)
+	}
+
+	_, err = relayClient.RelayWithCtx(context.Background(), input, nil)
+	c.True(provider.IsErrorCode(provider.OverServiceError, err))
+
+	input.Blockchain = "0001"
+
+	_, err = relayClient.RelayWithCtx(context.Background(), input, nil)
+	c.True(provider.IsErrorCode(provider.UnsupportedBlockchainError, err))
+
+	input.Blockchain = "0021"
+
+	server.AdvanceHeight(4)
+
+	_, err = relayClient.RelayWithCtx(context.Background(), input, nil)
+	c.True(provider.IsErrorCode(provider.InvalidBlockHeightError, err))
+}
+
+func TestServer_UnknownRoute(t *testing.T) {
+	c := require.New(t)
+
+	server := NewServer(Config{})
+	defer server.Close()
+
+	response, err := http.Post(server.URL+"/v1/query/unknown", "application/json", nil)
+	c.NoError(err)
+	defer response.Body.Close()
+
+	c.Equal(http.StatusNotFound, response.StatusCode)
+}
@@ -0,0 +1,229 @@
+package pockettest
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-network/pocket-core/app"
+	coreTypes "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/auth"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+)
+
+type rawTXParams struct {
+	Address     string `json:"address"`
+	RawHexBytes string `json:"raw_hex_bytes"`
+}
+
+// rawTXOutput is the output of rawtx, code and codespace are only set when the transaction is rejected
+type rawTXOutput struct {
+	Height    string `json:"height"`
+	TxHash    string `json:"txhash"`
+	Code      uint32 `json:"code,omitempty"`
+	Codespace string `json:"codespace,omitempty"`
+	RawLog    string `json:"raw_log"`
+}
+
+func (s *Server) handleRawTX(w http.ResponseWriter, body []byte) {
+	var params rawTXParams
+	if !decodeParams(w, body, &params) {
+		return
+	}
+
+	txBytes, err := hex.DecodeString(params.RawHexBytes)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	decodedTx, sdkErr := auth.DefaultTxDecoder(app.Codec())(txBytes, -1)
+	if sdkErr != nil {
+		writeRPCError(w, http.StatusBadRequest, sdkErr.Error())
+		return
+	}
+
+	tx, ok := decodedTx.(authTypes.StdTx)
+	if !ok {
+		writeRPCError(w, http.StatusBadRequest, "unknown transaction type")
+		return
+	}
+
+	pending := &pendingTx{
+		hash:  txHash(txBytes),
+		bytes: txBytes,
+		tx:    tx,
+	}
+
+	if sdkErr := s.checkTx(pending); sdkErr != nil {
+		result := sdkErr.Result()
+
+		writeJSON(w, http.StatusOK, &rawTXOutput{
+			Height:    "0",
+			TxHash:    pending.hash,
+			Code:      uint32(result.Code),
+			Codespace: string(result.Codespace),
+			RawLog:    result.Log,
+		})
+
+		return
+	}
+
+	s.ledger.mempool = append(s.ledger.mempool, pending)
+
+	if s.autoCommit {
+		s.commit()
+	}
+
+	writeJSON(w, http.StatusOK, &rawTXOutput{
+		Height: "0",
+		TxHash: pending.hash,
+		RawLog: "[]",
+	})
+}
+
+// checkTx validates the transaction like Pocket does before accepting it into the mempool
+func (s *Server) checkTx(pending *pendingTx) coreTypes.Error {
+	tx := pending.tx
+
+	if err := tx.ValidateBasic(); err != nil {
+		return err
+	}
+
+	maxMemoCharacters := getIntParam(s.params, "auth/MaxMemoCharacters", 75)
+	if len(tx.Memo) > maxMemoCharacters {
+		return coreTypes.ErrMemoTooLarge(fmt.Sprintf("maximum number of characters is %d but received %d characters",
+			maxMemoCharacters, len(tx.Memo)))
+	}
+
+	if s.ledger.isKnownTx(pending.hash) {
+		return authTypes.ErrDuplicateTx(auth.ModuleName, pending.hash)
+	}
+
+	if err := s.verifySignature(tx); err != nil {
+		return err
+	}
+
+	fee := expectedFee(s.params, tx.Msg)
+	if !tx.Fee.IsAllGTE(fee) {
+		return authTypes.ErrInsufficientFee(auth.ModuleName, fee, tx.Fee)
+	}
+
+	signer := coreTypes.Address(tx.Signature.PublicKey.Address())
+	if s.ledger.account(signer.String()).balance.Cmp(tx.Fee.AmountOf(coreTypes.DefaultStakeDenom).BigInt()) < 0 {
+		return authTypes.ErrInsufficientBalance(auth.ModuleName, signer, tx.Fee)
+	}
+
+	return nil
+}
+
+// verifySignature checks the transaction is signed by one of the signers of its message
+func (s *Server) verifySignature(tx authTypes.StdTx) coreTypes.Error {
+	publicKey := tx.Signature.PublicKey
+	if publicKey == nil {
+		return coreTypes.ErrInvalidPubKey("transaction has no public key")
+	}
+
+	signBytes, err := auth.StdSignBytes(s.chainID, tx.Entropy, tx.Fee, tx.Msg, tx.Memo)
+	if err != nil {
+		return coreTypes.ErrInternal(err.Error())
+	}
+
+	for _, signer := range tx.Msg.GetSigners() {
+		if signer.Equals(coreTypes.Address(publicKey.Address())) && publicKey.VerifyBytes(signBytes, tx.Signature.Signature) {
+			return nil
+		}
+	}
+
+	return coreTypes.ErrUnauthorized("signature verification failed for the transaction")
+}
+
+// deliverTx charges the fee and applies the message, a failed message still pays its fee like on Pocket
+func (s *Server) deliverTx(pending *pendingTx) *provider.TxResult {
+	tx := pending.tx
+	signerAddress := coreTypes.Address(tx.Signature.PublicKey.Address())
+	signer := signerAddress.String()
+
+	result := &provider.TxResult{
+		MessageType: tx.Msg.Type(),
+		Signer:      signer,
+	}
+
+	if recipient := tx.Msg.GetRecipient(); len(recipient) > 0 {
+		result.Recipient = recipient.String()
+	}
+
+	fee := tx.Fee.AmountOf(coreTypes.DefaultStakeDenom).BigInt()
+
+	if !s.ledger.debit(signer, fee) {
+		return withError(result, authTypes.ErrInsufficientBalance(auth.ModuleName, signerAddress, tx.Fee))
+	}
+
+	if err := s.applyMsg(signer, tx.Msg); err != nil {
+		return withError(result, err)
+	}
+
+	return result
+}
+
+func withError(result *provider.TxResult, err coreTypes.Error) *provider.TxResult {
+	sdkResult := err.Result()
+
+	result.Code = int(sdkResult.Code)
+	result.Codespace = string(sdkResult.Codespace)
+	result.Log = sdkResult.Log
+
+	return result
+}
+
+// toStdTx converts a decoded transaction to its RPC representation
+func toStdTx(tx *authTypes.StdTx) *provider.StdTx {
+	stdTx := &provider.StdTx{
+		Entropy: tx.Entropy,
+		Memo:    tx.Memo,
+		Msg:     &provider.TxMsg{},
+		Signature: &provider.TxSignature{
+			PubKey:    tx.Signature.PublicKey.RawString(),
+			Signature: base64.StdEncoding.EncodeToString(tx.Signature.Signature),
+		},
+	}
+
+	for _, coin := range tx.Fee {
+		stdTx.Fee = append(stdTx.Fee, &provider.Fee{Amount: coin.Amount.String(), Denom: coin.Denom})
+	}
+
+	// Messages are shown with their amino JSON, i.e. {"type":"pos/Send","value":{...}}
+	if msgJSON, err := app.Codec().MarshalJSON(tx.Msg); err == nil {
+		_ = json.Unmarshal(msgJSON, stdTx.Msg)
+	}
+
+	return stdTx
+}
+
+// maxRelays calculates the relays per session of an app with the given stake like Pocket does
+// participation rate is considered off
+func (s *Server) maxRelays(stakedTokens *big.Int) *big.Int {
+	if stakedTokens == nil {
+		return big.NewInt(0)
+	}
+
+	baseRelays, _ := new(big.Int).SetString(getParam(s.params, "application/BaseRelaysPerPOKT"), 10)
+	if baseRelays == nil {
+		baseRelays = big.NewInt(0)
+	}
+
+	stabilityAdjustment, _ := new(big.Int).SetString(getParam(s.params, "application/StabilityAdjustment"), 10)
+	if stabilityAdjustment == nil {
+		stabilityAdjustment = big.NewInt(0)
+	}
+
+	// BaseRelaysPerPOKT is a percentage and stake is in upokt
+	relays := new(big.Int).Mul(stakedTokens, baseRelays)
+	relays.Quo(relays, big.NewInt(100*1000000))
+
+	return relays.Add(relays, stabilityAdjustment)
+}
@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// JSONRPCParseError error code when the request is not valid JSON
+	JSONRPCParseError = -32700
+	// JSONRPCInvalidRequest error code when the request is not a valid JSON-RPC request
+	JSONRPCInvalidRequest = -32600
+	// JSONRPCMethodNotFound error code when the requested method does not exist
+	JSONRPCMethodNotFound = -32601
+	// JSONRPCInvalidParams error code when the method params are invalid
+	JSONRPCInvalidParams = -32602
+	// JSONRPCInternalError error code when the blockchain failed internally
+	JSONRPCInternalError = -32603
+
+	jsonRPCServerErrorMin = -32099
+	jsonRPCServerErrorMax = -32000
+)
+
+// ResponseClassifier classifies the response of a relay into a status code and, when the
+// blockchain reported one, a structured error
+type ResponseClassifier interface {
+	Classify(response string) (int, *ChainError)
+}
+
+// ChainError represents an error reported by the relayed blockchain inside a relay response
+type ChainError struct {
+	Code    int
+	Message string
+	// Data holds any additional error information sent by the blockchain as raw JSON
+	Data string
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("blockchain responded with code: %d and message: %s", e.Code, e.Message)
+}
+
+// JSONRPCClassifier classifies JSON-RPC 2.0 responses, including batches
+type JSONRPCClassifier struct {
+	// ErrorStatuses overrides the status code returned for specific JSON-RPC error codes
+	ErrorStatuses map[int]int
+}
+
+// NewJSONRPCClassifier returns a classifier for standard JSON-RPC 2.0 blockchains
+func NewJSONRPCClassifier() *JSONRPCClassifier {
+	return &JSONRPCClassifier{
+		ErrorStatuses: map[int]int{},
+	}
+}
+
+// NewSolanaClassifier returns a JSON-RPC classifier aware of Solana specific error codes
+func NewSolanaClassifier() *JSONRPCClassifier {
+	return &JSONRPCClassifier{
+		ErrorStatuses: map[int]int{
+			-32002: http.StatusBadRequest,         // Transaction simulation failed
+			-32003: http.StatusBadRequest,         // Transaction signature verification failure
+			-32004: http.StatusNotFound,           // Block not available for slot
+			-32005: http.StatusServiceUnavailable, // Node is unhealthy
+			-32007: http.StatusNotFound,           // Slot was skipped or missing due to ledger jump
+			-32009: http.StatusNotFound,           // Slot was skipped or missing in long-term storage
+			-32016: http.StatusServiceUnavailable, // Minimum context slot has not been reached
+		},
+	}
+}
+
+type jsonRPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *jsonRPCError   `json:"error"`
+}
+
+type jsonRPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (e *jsonRPCError) toChainError() *ChainError {
+	return &ChainError{
+		Code:    e.Code,
+		Message: e.Message,
+		Data:    string(e.Data),
+	}
+}
+
+// Classify returns 200 for responses with a result and the status matching the error code otherwise
+// Batches are successful if at least one of their responses is, the first error is always returned
+func (c *JSONRPCClassifier) Classify(response string) (int, *ChainError) {
+	trimmed := bytes.TrimSpace([]byte(response))
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var batch []jsonRPCResponse
+		if err := json.Unmarshal(trimmed, &batch); err != nil {
+			return DefaultStatusCode, nil
+		}
+
+		return c.classifyBatch(batch)
+	}
+
+	var single jsonRPCResponse
+	if err := json.Unmarshal(trimmed, &single); err != nil {
+		return DefaultStatusCode, nil
+	}
+
+	return c.classifySingle(&single)
+}
+
+func (c *JSONRPCClassifier) classifyBatch(batch []jsonRPCResponse) (int, *ChainError) {
+	if len(batch) == 0 {
+		return DefaultStatusCode, nil
+	}
+
+	var (
+		firstStatus int
+		firstErr    *ChainError
+	)
+
+	succeeded := false
+
+	for i := range batch {
+		status, chainErr := c.classifySingle(&batch[i])
+		if chainErr == nil && status == http.StatusOK {
+			succeeded = true
+		}
+
+		if chainErr != nil && firstErr == nil {
+			firstStatus, firstErr = status, chainErr
+		}
+	}
+
+	if succeeded || firstErr == nil {
+		return http.StatusOK, firstErr
+	}
+
+	return firstStatus, firstErr
+}
+
+func (c *JSONRPCClassifier) classifySingle(response *jsonRPCResponse) (int, *ChainError) {
+	if response.Error != nil {
+		return c.errorStatus(response.Error.Code), response.Error.toChainError()
+	}
+
+	if response.Result != nil {
+		return http.StatusOK, nil
+	}
+
+	return DefaultStatusCode, nil
+}
+
+func (c *JSONRPCClassifier) errorStatus(code int) int {
+	if status, ok := c.ErrorStatuses[code]; ok {
+		return status
+	}
+
+	switch {
+	case code == JSONRPCParseError, code == JSONRPCInvalidRequest, code == JSONRPCInvalidParams:
+		return http.StatusBadRequest
+	case code == JSONRPCMethodNotFound:
+		return http.StatusNotFound
+	case code == JSONRPCInternalError:
+		return http.StatusInternalServerError
+	case code >= jsonRPCServerErrorMin && code <= jsonRPCServerErrorMax:
+		return http.StatusInternalServerError
+	default:
+		// Application defined errors are caused by the request itself, i.e. reverted calls
+		return http.StatusBadRequest
+	}
+}
+
+// TendermintClassifier classifies Tendermint RPC responses
+// On top of JSON-RPC errors it reports results carrying a non zero ABCI code, i.e. failed broadcast_tx_sync
+type TendermintClassifier struct {
+	jsonRPC *JSONRPCClassifier
+}
+
+// NewTendermintClassifier returns a classifier for Tendermint based blockchains
+func NewTendermintClassifier() *TendermintClassifier {
+	return &TendermintClassifier{
+		jsonRPC: NewJSONRPCClassifier(),
+	}
+}
+
+type tendermintResult struct {
+	Code      int    `json:"code"`
+	Log       string `json:"log"`
+	Codespace string `json:"codespace"`
+}
+
+// Classify returns the status and error of a Tendermint RPC response
+func (c *TendermintClassifier) Classify(response string) (int, *ChainError) {
+	status, chainErr := c.jsonRPC.Classify(response)
+	if status != http.StatusOK || chainErr != nil {
+		return status, chainErr
+	}
+
+	var output struct {
+		Result tendermintResult `json:"result"`
+	}
+
+	// Results that are not objects are valid responses with no ABCI code
+	if err := json.Unmarshal([]byte(response), &output); err != nil || output.Result.Code == 0 {
+		return status, nil
+	}
+
+	return http.StatusBadRequest, &ChainError{
+		Code:    output.Result.Code,
+		Message: output.Result.Log,
+		Data:    output.Result.Codespace,
+	}
+}
+
+// RESTClassifier classifies responses of REST blockchains, which carry no JSON-RPC envelope
+type RESTClassifier struct{}
+
+// NewRESTClassifier returns a classifier for REST blockchains
+func NewRESTClassifier() *RESTClassifier {
+	return &RESTClassifier{}
+}
+
+type restErrorBody struct {
+	Code    json.RawMessage `json:"code"`
+	Message string          `json:"message"`
+	Error   json.RawMessage `json:"error"`
+}
+
+// Classify returns 200 for JSON responses with no error field nor an HTTP error code, otherwise the status
+// is taken from the error code when it is an HTTP error status or guessed from the body
+func (c *RESTClassifier) Classify(response string) (int, *ChainError) {
+	if !json.Valid([]byte(response)) {
+		status := extractStatusFromResponse(response)
+		if status >= http.StatusBadRequest {
+			return status, &ChainError{Code: status, Message: strings.TrimSpace(response)}
+		}
+
+		return status, nil
+	}
+
+	var body restErrorBody
+
+	// Responses that are not objects, i.e. arrays or strings, can't hold an error
+	if err := json.Unmarshal([]byte(response), &body); err != nil {
+		return http.StatusOK, nil
+	}
+
+	// Non numeric codes are ignored, the error field is enough to flag those responses
+	var code int64
+	_ = json.Unmarshal(body.Code, &code)
+
+	// Other codes, i.e. 200 or application codes, are part of successful responses
+	isHTTPError := code >= http.StatusBadRequest && code < 600
+
+	hasError := len(body.Error) > 0 && string(body.Error) != "null"
+	if !hasError && !isHTTPError {
+		return http.StatusOK, nil
+	}
+
+	chainErr := &ChainError{
+		Code:    int(code),
+		Message: body.Message,
+		Data:    string(body.Error),
+	}
+
+	if chainErr.Message == "" && hasError {
+		chainErr.Message = restErrorMessage(body.Error)
+	}
+
+	if isHTTPError {
+		return int(code), chainErr
+	}
+
+	return http.StatusInternalServerError, chainErr
+}
+
+func restErrorMessage(rawError json.RawMessage) string {
+	var message string
+	if err := json.Unmarshal(rawError, &message); err == nil {
+		return message
+	}
+
+	var nested struct {
+		Message string `json:"message"`
+	}
+
+	if err := json.Unmarshal(rawError, &nested); err == nil {
+		return nested.Message
+	}
+
+	return ""
+}
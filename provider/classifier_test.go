@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/pokt-foundation/utils-go/mock-client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRPCClassifier_Classify(t *testing.T) {
+	c := require.New(t)
+
+	classifier := NewJSONRPCClassifier()
+
+	status, chainErr := classifier.Classify(`{"id":1,"jsonrpc":"2.0","result":"0xdd03e4"}`)
+	c.Equal(http.StatusOK, status)
+	c.Nil(chainErr)
+
+	status, chainErr = classifier.Classify(`{"id":1,"jsonrpc":"2.0","result":null}`)
+	c.Equal(http.StatusOK, status)
+	c.Nil(chainErr)
+
+	status, chainErr = classifier.Classify(`{"id":1,"jsonrpc":"2.0","error":{"code":-32601,"message":"the method result_x does not exist"}}`)
+	c.Equal(http.StatusNotFound, status)
+	c.Equal(&ChainError{Code: JSONRPCMethodNotFound, Message: "the method result_x does not exist"}, chainErr)
+
+	status, chainErr = classifier.Classify(`{"id":1,"jsonrpc":"2.0","error":{"code":-32000,"message":"header not found"}}`)
+	c.Equal(http.StatusInternalServerError, status)
+	c.Equal(-32000, chainErr.Code)
+
+	status, chainErr = classifier.Classify(`{"id":1,"jsonrpc":"2.0","error":{"code":3,"message":"execution reverted","data":"0x08c379a0"}}`)
+	c.Equal(http.StatusBadRequest, status)
+	c.Equal(`"0x08c379a0"`, chainErr.Data)
+
+	status, chainErr = classifier.Classify(`[{"id":1,"result":"0x1"},{"id":2,"error":{"code":-32602,"message":"invalid argument"}}]`)
+	c.Equal(http.StatusOK, status)
+	c.Equal(JSONRPCInvalidParams, chainErr.Code)
+
+	status, chainErr = classifier.Classify(`[{"id":1,"error":{"code":-32602,"message":"invalid argument"}}]`)
+	c.Equal(http.StatusBadRequest, status)
+	c.Equal(JSONRPCInvalidParams, chainErr.Code)
+
+	status, chainErr = classifier.Classify(`not json`)
+	c.Equal(DefaultStatusCode, status)
+	c.Nil(chainErr)
+}
+
+func TestSolanaClassifier_Classify(t *testing.T) {
+	c := require.New(t)
+
+	classifier := NewSolanaClassifier()
+
+	status, chainErr := classifier.Classify(`{"jsonrpc":"2.0","error":{"code":-32007,"message":"Slot 1 was skipped"},"id":1}`)
+	c.Equal(http.StatusNotFound, status)
+	c.Equal(-32007, chainErr.Code)
+
+	status, chainErr = classifier.Classify(`{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is unhealthy"},"id":1}`)
+	c.Equal(http.StatusServiceUnavailable, status)
+	c.NotNil(chainErr)
+}
+
+func TestTendermintClassifier_Classify(t *testing.T) {
+	c := require.New(t)
+
+	classifier := NewTendermintClassifier()
+
+	status, chainErr := classifier.Classify(`{"jsonrpc":"2.0","id":-1,"result":{"code":0,"data":"","log":"[]","hash":"ABC"}}`)
+	c.Equal(http.StatusOK, status)
+	c.Nil(chainErr)
+
+	status, chainErr = classifier.Classify(`{"jsonrpc":"2.0","id":-1,"result":{"code":5,"log":"insufficient funds","codespace":"sdk"}}`)
+	c.Equal(http.StatusBadRequest, status)
+	c.Equal(&ChainError{Code: 5, Message: "insufficient funds", Data: "sdk"}, chainErr)
+
+	status, chainErr = classifier.Classify(`{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"height 10 must be less than or equal to the current blockchain height 5"}}`)
+	c.Equal(http.StatusInternalServerError, status)
+	c.Equal(`"height 10 must be less than or equal to the current blockchain height 5"`, chainErr.Data)
+
+	status, chainErr = classifier.Classify(`{"jsonrpc":"2.0","id":-1,"result":"ok"}`)
+	c.Equal(http.StatusOK, status)
+	c.Nil(chainErr)
+}
+
+func TestRESTClassifier_Classify(t *testing.T) {
+	c := require.New(t)
+
+	classifier := NewRESTClassifier()
+
+	status, chainErr := classifier.Classify(`{"height":"21","result":{"block":{}}}`)
+	c.Equal(http.StatusOK, status)
+	c.Nil(chainErr)
+
+	status, chainErr = classifier.Classify(`[1,2,3]`)
+	c.Equal(http.StatusOK, status)
+	c.Nil(chainErr)
+
+	status, chainErr = classifier.Classify(`{"code":404,"message":"block not found"}`)
+	c.Equal(http.StatusNotFound, status)
+	c.Equal("block not found", chainErr.Message)
+
+	// Codes that are not HTTP errors are part of the response unless there is an error field
+	status, chainErr = classifier.Classify(`{"code":200,"message":"ok","data":{}}`)
+	c.Equal(http.StatusOK, status)
+	c.Nil(chainErr)
+
+	status, chainErr = classifier.Classify(`{"code":1,"data":{"balance":"21"}}`)
+	c.Equal(http.StatusOK, status)
+	c.Nil(chainErr)
+
+	status, chainErr = classifier.Classify(`{"code":5,"message":"account not found","error":"account not found"}`)
+	c.Equal(http.StatusInternalServerError, status)
+	c.Equal(5, chainErr.Code)
+
+	status, chainErr = classifier.Classify(`{"error":"result is unavailable"}`)
+	c.Equal(http.StatusInternalServerError, status)
+	c.Equal("result is unavailable", chainErr.Message)
+
+	status, chainErr = classifier.Classify(`{"error":{"message":"bad request"}}`)
+	c.Equal(http.StatusInternalServerError, status)
+	c.Equal("bad request", chainErr.Message)
+
+	status, chainErr = classifier.Classify(`404 page not found`)
+	c.Equal(http.StatusNotFound, status)
+	c.Equal("404 page not found", chainErr.Message)
+}
+
+func TestProvider_RegisterResponseClassifier(t *testing.T) {
+	c := require.New(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	provider := NewProvider("https://dummy.com", []string{"https://dummy.com"})
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", ClientRelayRoute), http.StatusOK, "samples/client_relay_chain_error.json")
+
+	input := &RelayInput{Proof: &RelayProof{Blockchain: "0021"}}
+
+	// The response contains the word result so the default heuristic marks it as successful
+	relay, err := provider.RelayWithCtx(context.Background(), "https://dummy.com", input, nil)
+	c.NoError(err)
+	c.Equal(http.StatusOK, relay.StatusCode)
+	c.Nil(relay.ChainError)
+
+	provider.RegisterResponseClassifier("0021", NewJSONRPCClassifier())
+
+	relay, err = provider.RelayWithCtx(context.Background(), "https://dummy.com", input, nil)
+	c.NoError(err)
+	c.Equal(http.StatusNotFound, relay.StatusCode)
+	c.Equal(JSONRPCMethodNotFound, relay.ChainError.Code)
+}
@@ -122,6 +122,14 @@ type DispatchRequestOptions struct {
 // RelayRequestOptions represents optional arguments for Relay request
 type RelayRequestOptions struct {
 	RejectSelfSignedCertificates bool
+	// MaxBodySize is the maximum amount of bytes read from the relay response body.
+	// Bigger responses fail with ResponseTooLargeError, zero means no limit
+	MaxBodySize int64
+}
+
+// GetSupportedChainsOptions represents optional arguments for GetSupportedChains request
+type GetSupportedChainsOptions struct {
+	Height int
 }
 
 // GetTransactionOptions represents the optional arguments for a GetTransaction request
@@ -14,6 +14,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pokt-foundation/pocket-go/utils"
@@ -36,6 +37,12 @@ var (
 
 	regexPatterns = []*regexp.Regexp{}
 
+	// notFoundMessages are the messages of the node for apps and nodes not found
+	notFoundMessages = []string{
+		"application does not exist for that address",
+		"validator not found for",
+	}
+
 	errorStatusCodesMap = map[string]int{
 		"context deadline exceeded (Client.Timeout exceeded while awaiting headers)": 408, // Request Timeout
 		"connection reset by peer":            503, // Service Unavailable
@@ -64,6 +71,9 @@ type Provider struct {
 	rpcURL      string
 	dispatchers []string
 	client      *client.Client
+
+	classifiersMu sync.RWMutex
+	classifiers   map[string]ResponseClassifier
 }
 
 // NewProvider returns Provider instance from input
@@ -72,9 +82,30 @@ func NewProvider(rpcURL string, dispatchers []string) *Provider {
 		rpcURL:      rpcURL,
 		dispatchers: dispatchers,
 		client:      client.NewDefaultClient(),
+		classifiers: map[string]ResponseClassifier{},
 	}
 }
 
+// RegisterResponseClassifier sets the classifier used for the relay responses of the given blockchain ID
+// Blockchains with no classifier registered have their status guessed from the response content
+func (p *Provider) RegisterResponseClassifier(blockchain string, classifier ResponseClassifier) {
+	p.classifiersMu.Lock()
+	defer p.classifiersMu.Unlock()
+
+	p.classifiers[blockchain] = classifier
+}
+
+func (p *Provider) getResponseClassifier(input *RelayInput) ResponseClassifier {
+	if input == nil || input.Proof == nil {
+		return nil
+	}
+
+	p.classifiersMu.RLock()
+	defer p.classifiersMu.RUnlock()
+
+	return p.classifiers[input.Proof.Blockchain]
+}
+
 func init() {
 	regexPatterns = []*regexp.Regexp{
 		regexp.MustCompile(`"code"\s*:\s*(\d+)`),       // Matches and captures any numeric status code after `"code":`
@@ -177,6 +208,22 @@ func returnRPCError(route V1RPCRoute, body io.ReadCloser) error {
 	return &output
 }
 
+// IsNotFoundError returns if the error is the one Pocket nodes respond when the queried app or node is not staked
+func IsNotFoundError(err error) bool {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != http.StatusBadRequest {
+		return false
+	}
+
+	for _, message := range notFoundMessages {
+		if strings.Contains(rpcErr.Message, message) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetBalance requests the balance of the specified address
 func (p *Provider) GetBalance(address string, options *GetBalanceOptions) (*big.Int, error) {
 	return p.GetBalanceWithCtx(context.Background(), address, options)
@@ -425,7 +472,7 @@ func (p *Provider) GetTransactionWithCtx(ctx context.Context, transactionHash st
 		params["prove"] = options.Prove
 	}
 
-	rawOutput, err := p.doPostRequest(context.Background(), "", params, QueryTXRoute, http.Header{})
+	rawOutput, err := p.doPostRequest(ctx, "", params, QueryTXRoute, http.Header{})
 
 	defer closeOrLog(rawOutput)
 
@@ -527,6 +574,44 @@ func (p *Provider) GetAllParamsWithCtx(ctx context.Context, options *GetAllParam
 	return &allParams, nil
 }
 
+// GetSupportedChains returns the relay chains supported by the network at the specified height
+func (p *Provider) GetSupportedChains(options *GetSupportedChainsOptions) ([]string, error) {
+	return p.GetSupportedChainsWithCtx(context.Background(), options)
+}
+
+// GetSupportedChainsWithCtx returns the relay chains supported by the network at the specified height
+func (p *Provider) GetSupportedChainsWithCtx(ctx context.Context, options *GetSupportedChainsOptions) ([]string, error) {
+	var height int
+
+	if options != nil {
+		height = options.Height
+	}
+
+	rawOutput, err := p.doPostRequest(ctx, "", map[string]int{
+		"height": height,
+	}, QuerySupportedChainsRoute, http.Header{})
+
+	defer closeOrLog(rawOutput)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := ioutil.ReadAll(rawOutput.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var chains []string
+
+	err = json.Unmarshal(bodyBytes, &chains)
+	if err != nil {
+		return nil, err
+	}
+
+	return chains, nil
+}
+
 // GetNodes returns a page of nodes known at the specified height and with options
 // empty options returns all validators, page < 1 returns the first page, per_page < 1 returns 10000 elements per page
 func (p *Provider) GetNodes(options *GetNodesOptions) (*GetNodesOutput, error) {
@@ -846,7 +931,7 @@ func (p *Provider) RelayWithCtx(ctx context.Context, rpcURL string, input *Relay
 		return defaultOutput, reqErr
 	}
 
-	bodyBytes, err := io.ReadAll(rawOutput.Body)
+	bodyBytes, err := readBody(rawOutput.Body, getMaxBodySize(options))
 	if err != nil {
 		return defaultOutput, err
 	}
@@ -856,7 +941,57 @@ func (p *Provider) RelayWithCtx(ctx context.Context, rpcURL string, input *Relay
 	}
 
 	// The statusCode will be overwritten based on the response
-	return parseRelaySuccesfulOutput(bodyBytes, statusCode)
+	return parseRelaySuccesfulOutput(bodyBytes, statusCode, p.getResponseClassifier(input))
+}
+
+// RelayStream does request to be relayed to a target blockchain without holding the whole response in memory
+// The returned output must be closed by the caller
+func (p *Provider) RelayStream(rpcURL string, input *RelayInput, options *RelayRequestOptions) (*RelayStreamOutput, error) {
+	return p.RelayStreamWithCtx(context.Background(), rpcURL, input, options)
+}
+
+// RelayStreamWithCtx does request to be relayed to a target blockchain without holding the whole response in memory
+// The status code only reflects the HTTP status of the request since the response is not inspected beforehand
+// The returned output must be closed by the caller
+func (p *Provider) RelayStreamWithCtx(ctx context.Context, rpcURL string, input *RelayInput, options *RelayRequestOptions) (*RelayStreamOutput, error) {
+	rawOutput, reqErr := p.doPostRequest(ctx, rpcURL, input, ClientRelayRoute, http.Header{})
+
+	statusCode := extractStatusFromRequest(rawOutput, reqErr)
+
+	if reqErr != nil {
+		defer closeOrLog(rawOutput)
+
+		if !errors.Is(reqErr, errOnRelayRequest) {
+			return nil, reqErr
+		}
+
+		bodyBytes, err := readBody(rawOutput.Body, getMaxBodySize(options))
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, parseRelayErrorOutput(bodyBytes, input.Proof.ServicerPubKey)
+	}
+
+	envelope, err := newRelayEnvelopeReader(newLimitedBody(rawOutput.Body, getMaxBodySize(options)))
+	if err != nil {
+		utils.CloseOrLog(rawOutput.Body)
+		return nil, err
+	}
+
+	return &RelayStreamOutput{
+		StatusCode: statusCode,
+		body:       rawOutput.Body,
+		envelope:   envelope,
+	}, nil
+}
+
+func getMaxBodySize(options *RelayRequestOptions) int64 {
+	if options == nil {
+		return 0
+	}
+
+	return options.MaxBodySize
 }
 
 func extractStatusFromRequest(rawOutput *http.Response, reqErr error) int {
@@ -898,7 +1033,7 @@ func extractStatusFromResponse(response string) int {
 	return DefaultStatusCode
 }
 
-func parseRelaySuccesfulOutput(bodyBytes []byte, requestStatusCode int) (*RelayOutput, error) {
+func parseRelaySuccesfulOutput(bodyBytes []byte, requestStatusCode int, classifier ResponseClassifier) (*RelayOutput, error) {
 	output := RelayOutput{
 		StatusCode: requestStatusCode,
 	}
@@ -908,11 +1043,15 @@ func parseRelaySuccesfulOutput(bodyBytes []byte, requestStatusCode int) (*RelayO
 		return &output, err
 	}
 
+	switch {
+	// A registered classifier knows how the blockchain reports errors so it takes precedence over the guessing below
+	case classifier != nil:
+		output.StatusCode, output.ChainError = classifier.Classify(output.Response)
 	// Check if there's explicitly a result field, if there's on mark it as success, otherwise check what's the potential status.
 	// for REST chain that doesn't return result in any of the call will be defaulted to 202 in extractStatusFromResponse
-	if strings.Contains(output.Response, resultText) {
+	case strings.Contains(output.Response, resultText):
 		output.StatusCode = http.StatusOK
-	} else {
+	default:
 		output.StatusCode = extractStatusFromResponse(output.Response)
 	}
 
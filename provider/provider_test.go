@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/big"
 	"net/http"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/jarcoal/httpmock"
@@ -434,6 +436,27 @@ func TestProvider_GetAllParamsWithCtx(t *testing.T) {
 	c.Equal("2109", relaysToTokensMultiplier)
 }
 
+func TestProvider_GetSupportedChains(t *testing.T) {
+	c := require.New(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	provider := NewProvider("https://dummy.com", []string{"https://dummy.com"})
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", QuerySupportedChainsRoute), http.StatusOK, "samples/query_supportedchains.json")
+
+	chains, err := provider.GetSupportedChains(nil)
+	c.NoError(err)
+	c.Equal([]string{"0001", "0003", "0004", "0009", "0021"}, chains)
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", QuerySupportedChainsRoute), http.StatusInternalServerError, "samples/query_supportedchains.json")
+
+	chains, err = provider.GetSupportedChainsWithCtx(context.Background(), &GetSupportedChainsOptions{Height: 21})
+	c.Equal(Err5xxOnConnection, err)
+	c.Empty(chains)
+}
+
 func TestProvider_GetNodes(t *testing.T) {
 	c := require.New(t)
 
@@ -537,6 +560,16 @@ func TestProvider_GetNode(t *testing.T) {
 	node, err = provider.GetNode("pjog", nil)
 	c.Equal(Err5xxOnConnection, err)
 	c.Empty(node)
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", QueryNodeRoute), http.StatusBadRequest, "samples/query_node_not_found.json")
+
+	_, err = provider.GetNode("pjog", nil)
+	c.True(IsNotFoundError(err))
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", QueryNodeRoute), http.StatusBadRequest, "samples/error_response.json")
+
+	_, err = provider.GetNode("pjog", nil)
+	c.False(IsNotFoundError(err))
 }
 
 func TestProvider_GetNodeWithCtx(t *testing.T) {
@@ -579,6 +612,16 @@ func TestProvider_GetApp(t *testing.T) {
 	app, err = provider.GetApp("pjog", nil)
 	c.Equal(Err5xxOnConnection, err)
 	c.Empty(app)
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", QueryAppRoute), http.StatusBadRequest, "samples/query_app_not_found.json")
+
+	_, err = provider.GetApp("pjog", nil)
+	c.True(IsNotFoundError(err))
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", QueryAppRoute), http.StatusBadRequest, "samples/error_response.json")
+
+	_, err = provider.GetApp("pjog", nil)
+	c.False(IsNotFoundError(err))
 }
 
 func TestProvider_GetAppWithCtx(t *testing.T) {
@@ -831,3 +874,86 @@ func TestProvider_RelayWithCtx(t *testing.T) {
 	c.Equal(ErrNonJSONResponse, err)
 	c.Empty(relay.Response)
 }
+
+func TestProvider_RelayWithCtxMaxBodySize(t *testing.T) {
+	c := require.New(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	provider := NewProvider("https://dummy.com", []string{"https://dummy.com"})
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", ClientRelayRoute), http.StatusOK, "samples/client_relay.json")
+
+	relay, err := provider.RelayWithCtx(context.Background(), "https://dummy.com", &RelayInput{}, &RelayRequestOptions{MaxBodySize: 10})
+	var sizeErr *ResponseTooLargeError
+	c.ErrorAs(err, &sizeErr)
+	c.Equal(int64(10), sizeErr.Limit)
+	c.Empty(relay.Response)
+
+	relay, err = provider.RelayWithCtx(context.Background(), "https://dummy.com", &RelayInput{}, &RelayRequestOptions{MaxBodySize: 1 << 20})
+	c.NoError(err)
+	c.Equal(http.StatusOK, relay.StatusCode)
+}
+
+func TestProvider_RelayStreamWithCtx(t *testing.T) {
+	c := require.New(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	provider := NewProvider("https://dummy.com", []string{"https://dummy.com"})
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", ClientRelayRoute), http.StatusOK, "samples/client_relay.json")
+
+	relay, err := provider.RelayStream("https://dummy.com", &RelayInput{}, nil)
+	c.NoError(err)
+
+	response, err := io.ReadAll(relay)
+	c.NoError(err)
+	c.Equal(`{"id":3905054414,"jsonrpc":"2.0","result":"0xdd03e4"}`, string(response))
+	c.Equal("abfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabf", relay.Signature())
+	c.Equal(DefaultStatusCode, relay.StatusCode)
+	c.NoError(relay.Close())
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", ClientRelayRoute), http.StatusOK, "samples/client_relay_stream.json")
+
+	relay, err = provider.RelayStreamWithCtx(context.Background(), "https://dummy.com", &RelayInput{}, nil)
+	c.NoError(err)
+
+	response, err = io.ReadAll(iotest.OneByteReader(relay))
+	c.NoError(err)
+	c.Equal("{\"id\":1,\"jsonrpc\":\"2.0\",\"result\":\"café 🚀\\n\\\"quoted\\\"\"}", string(response))
+	c.Equal("abfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabfabf", relay.Signature())
+	c.NoError(relay.Close())
+
+	relay, err = provider.RelayStreamWithCtx(context.Background(), "https://dummy.com", &RelayInput{}, &RelayRequestOptions{MaxBodySize: 50})
+	var sizeErr *ResponseTooLargeError
+	c.ErrorAs(err, &sizeErr)
+	c.Nil(relay)
+
+	relay, err = provider.RelayStreamWithCtx(context.Background(), "https://dummy.com", &RelayInput{}, &RelayRequestOptions{MaxBodySize: 120})
+	c.NoError(err)
+
+	_, err = io.ReadAll(relay)
+	c.ErrorAs(err, &sizeErr)
+	c.NoError(relay.Close())
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", ClientRelayRoute), http.StatusBadRequest, "samples/client_relay_error.json")
+
+	relay, err = provider.RelayStreamWithCtx(context.Background(), "https://dummy.com", &RelayInput{Proof: &RelayProof{ServicerPubKey: "PJOG"}}, nil)
+	c.True(IsErrorCode(EmptyPayloadDataError, err))
+	c.Nil(relay)
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", ClientRelayRoute), http.StatusInternalServerError, "samples/client_relay.json")
+
+	relay, err = provider.RelayStreamWithCtx(context.Background(), "https://dummy.com", &RelayInput{}, nil)
+	c.Equal(Err5xxOnConnection, err)
+	c.Nil(relay)
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", ClientRelayRoute), http.StatusOK, "samples/query_height.json")
+
+	relay, err = provider.RelayStreamWithCtx(context.Background(), "https://dummy.com", &RelayInput{}, nil)
+	c.Equal(ErrMalformedRelayResponse, err)
+	c.Nil(relay)
+}
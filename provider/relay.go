@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"io"
 )
 
 // RelayInput represents input needed to do a Relay to Pocket
@@ -16,6 +17,45 @@ type RelayOutput struct {
 	Response   string `json:"response"`
 	Signature  string `json:"signature"`
 	StatusCode int    `json:"statusCode"`
+	// ChainError is the error reported by the blockchain, only set when a ResponseClassifier
+	// is registered for the relayed blockchain
+	ChainError *ChainError `json:"-"`
+}
+
+// RelayStreamOutput represents a Relay RPC output whose response is read incrementally
+// Read returns the unescaped inner response, Signature is only available once the response is fully read
+type RelayStreamOutput struct {
+	StatusCode int
+
+	body     io.ReadCloser
+	envelope *relayEnvelopeReader
+}
+
+// Read reads the inner response of the relay
+func (o *RelayStreamOutput) Read(p []byte) (int, error) {
+	return o.envelope.Read(p)
+}
+
+// Signature returns the signature of the relay response
+// it is empty until the response has been read to the end
+func (o *RelayStreamOutput) Signature() string {
+	return o.envelope.signature
+}
+
+// Close closes the underlying response body
+func (o *RelayStreamOutput) Close() error {
+	return o.body.Close()
+}
+
+// ResponseTooLargeError represents the error when a response body exceeds the configured maximum size
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds maximum size of %d bytes", e.Limit)
 }
 
 // RelayMeta represents metadata of a relay
@@ -114,3 +154,8 @@ func IsErrorCode(code RelayErrorCode, err error) bool {
 
 	return castedErr.Code == code
 }
+
+// IsExhaustionError returns if the error reports the app ran out of relays for its session
+func IsExhaustionError(err error) bool {
+	return IsErrorCode(OverServiceError, err) || IsErrorCode(EvidencedSealedError, err)
+}
@@ -0,0 +1,413 @@
+package provider
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	responseField  = "response"
+	signatureField = "signature"
+)
+
+// ErrMalformedRelayResponse error when the relay response envelope is not valid JSON or has no response field
+var ErrMalformedRelayResponse = errors.New("malformed relay response")
+
+// limitedBody wraps a response body and fails with ResponseTooLargeError once more than limit bytes are read
+// A limit lower or equal than zero means there is no limit
+type limitedBody struct {
+	reader io.Reader
+	limit  int64
+	read   int64
+}
+
+func newLimitedBody(reader io.Reader, limit int64) *limitedBody {
+	return &limitedBody{
+		reader: reader,
+		limit:  limit,
+	}
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.limit <= 0 {
+		return l.reader.Read(p)
+	}
+
+	if l.read >= l.limit {
+		// Only fail if there is actually data left past the limit
+		var probe [1]byte
+
+		n, err := l.reader.Read(probe[:])
+		if n > 0 {
+			return 0, &ResponseTooLargeError{Limit: l.limit}
+		}
+
+		return 0, err
+	}
+
+	if remaining := l.limit - l.read; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := l.reader.Read(p)
+	l.read += int64(n)
+
+	return n, err
+}
+
+func readBody(body io.Reader, limit int64) ([]byte, error) {
+	return io.ReadAll(newLimitedBody(body, limit))
+}
+
+// relayEnvelopeReader decodes the relay envelope `{"signature": "...", "response": "..."}` incrementally
+// and exposes the unescaped content of the response field as an io.Reader
+type relayEnvelopeReader struct {
+	body      *bufio.Reader
+	signature string
+	inString  bool
+	pending   []byte
+}
+
+func newRelayEnvelopeReader(body io.Reader) (*relayEnvelopeReader, error) {
+	reader := &relayEnvelopeReader{
+		body: bufio.NewReader(body),
+	}
+
+	c, err := reader.nextNonSpace()
+	if err != nil {
+		return nil, err
+	}
+
+	if c != '{' {
+		return nil, ErrMalformedRelayResponse
+	}
+
+	if err := reader.parseFields(true); err != nil {
+		return nil, err
+	}
+
+	// The envelope ended without a response string, i.e. the node answered another route
+	if !reader.inString {
+		return nil, ErrMalformedRelayResponse
+	}
+
+	return reader, nil
+}
+
+// Read reads the unescaped response field, once it is fully read the rest of the envelope is parsed
+func (r *relayEnvelopeReader) Read(p []byte) (int, error) {
+	if !r.inString {
+		return 0, io.EOF
+	}
+
+	n := 0
+
+	for n < len(p) {
+		if len(r.pending) > 0 {
+			copied := copy(p[n:], r.pending)
+			r.pending = r.pending[copied:]
+			n += copied
+
+			continue
+		}
+
+		// Avoid blocking on the network while already holding data for the caller
+		if n > 0 && r.body.Buffered() == 0 {
+			return n, nil
+		}
+
+		b, err := r.readByte()
+		if err != nil {
+			return n, err
+		}
+
+		switch b {
+		case '"':
+			r.inString = false
+
+			if err := r.finishField(false); err != nil {
+				return n, err
+			}
+
+			if n == 0 {
+				return 0, io.EOF
+			}
+
+			return n, nil
+		case '\\':
+			decoded, err := r.readEscape()
+			if err != nil {
+				return n, err
+			}
+
+			r.pending = decoded
+		default:
+			p[n] = b
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// parseFields parses the envelope fields after the opening brace or a comma
+// when stopAtResponse is true it returns positioned inside the response string
+func (r *relayEnvelopeReader) parseFields(stopAtResponse bool) error {
+	c, err := r.nextNonSpace()
+	if err != nil {
+		return err
+	}
+
+	if c == '}' {
+		return nil
+	}
+
+	if c != '"' {
+		return ErrMalformedRelayResponse
+	}
+
+	key, err := r.readString()
+	if err != nil {
+		return err
+	}
+
+	c, err = r.nextNonSpace()
+	if err != nil {
+		return err
+	}
+
+	if c != ':' {
+		return ErrMalformedRelayResponse
+	}
+
+	c, err = r.nextNonSpace()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case key == responseField && stopAtResponse && c == '"':
+		r.inString = true
+		return nil
+	case key == signatureField && c == '"':
+		r.signature, err = r.readString()
+	default:
+		err = r.skipValue(c)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return r.finishField(stopAtResponse)
+}
+
+// finishField consumes the separator after a field value and keeps parsing if there are more fields
+func (r *relayEnvelopeReader) finishField(stopAtResponse bool) error {
+	c, err := r.nextNonSpace()
+	if err != nil {
+		return err
+	}
+
+	switch c {
+	case ',':
+		return r.parseFields(stopAtResponse)
+	case '}':
+		return nil
+	default:
+		return ErrMalformedRelayResponse
+	}
+}
+
+// readString reads a JSON string whose opening quote was already consumed
+func (r *relayEnvelopeReader) readString() (string, error) {
+	raw := []byte{'"'}
+	escaped := false
+
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return "", err
+		}
+
+		raw = append(raw, b)
+
+		if escaped {
+			escaped = false
+			continue
+		}
+
+		if b == '\\' {
+			escaped = true
+			continue
+		}
+
+		if b == '"' {
+			break
+		}
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", ErrMalformedRelayResponse
+	}
+
+	return value, nil
+}
+
+// skipValue discards a JSON value whose first byte was already consumed
+func (r *relayEnvelopeReader) skipValue(first byte) error {
+	switch first {
+	case '"':
+		_, err := r.readString()
+		return err
+	case '{', '[':
+		return r.skipComposite()
+	}
+
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return err
+		}
+
+		if b == ',' || b == '}' || b == ']' || isSpace(b) {
+			return r.body.UnreadByte()
+		}
+	}
+}
+
+// skipComposite discards an object or array whose opening byte was already consumed
+func (r *relayEnvelopeReader) skipComposite() error {
+	depth := 1
+
+	for depth > 0 {
+		b, err := r.readByte()
+		if err != nil {
+			return err
+		}
+
+		switch b {
+		case '"':
+			if _, err := r.readString(); err != nil {
+				return err
+			}
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		}
+	}
+
+	return nil
+}
+
+func (r *relayEnvelopeReader) readEscape() ([]byte, error) {
+	b, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch b {
+	case '"', '\\', '/':
+		return []byte{b}, nil
+	case 'b':
+		return []byte{'\b'}, nil
+	case 'f':
+		return []byte{'\f'}, nil
+	case 'n':
+		return []byte{'\n'}, nil
+	case 'r':
+		return []byte{'\r'}, nil
+	case 't':
+		return []byte{'\t'}, nil
+	case 'u':
+		return r.readUnicodeEscape()
+	default:
+		return nil, ErrMalformedRelayResponse
+	}
+}
+
+func (r *relayEnvelopeReader) readUnicodeEscape() ([]byte, error) {
+	char, err := r.readHexRune()
+	if err != nil {
+		return nil, err
+	}
+
+	if utf16.IsSurrogate(char) {
+		// A valid surrogate pair is written as two consecutive escapes
+		next, err := r.body.Peek(2)
+		if err == nil && next[0] == '\\' && next[1] == 'u' {
+			if _, err := r.body.Discard(2); err != nil {
+				return nil, err
+			}
+
+			low, err := r.readHexRune()
+			if err != nil {
+				return nil, err
+			}
+
+			char = utf16.DecodeRune(char, low)
+		} else {
+			char = utf8.RuneError
+		}
+	}
+
+	buf := make([]byte, utf8.UTFMax)
+
+	return buf[:utf8.EncodeRune(buf, char)], nil
+}
+
+func (r *relayEnvelopeReader) readHexRune() (rune, error) {
+	hex := make([]byte, 4)
+
+	if _, err := io.ReadFull(r.body, hex); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+
+	value, err := strconv.ParseUint(string(hex), 16, 16)
+	if err != nil {
+		return 0, ErrMalformedRelayResponse
+	}
+
+	return rune(value), nil
+}
+
+func (r *relayEnvelopeReader) nextNonSpace() (byte, error) {
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return 0, err
+		}
+
+		if !isSpace(b) {
+			return b, nil
+		}
+	}
+}
+
+func (r *relayEnvelopeReader) readByte() (byte, error) {
+	b, err := r.body.ReadByte()
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+
+	return b, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
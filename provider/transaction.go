@@ -4,8 +4,11 @@ package provider
 type SendTransactionOutput struct {
 	Height string `json:"height"`
 	Txhash string `json:"txhash"`
-	RawLog string `json:"raw_log"`
-	Logs   []struct {
+	// Code and Codespace are only set when the node rejects the transaction
+	Code      int    `json:"code"`
+	Codespace string `json:"codespace"`
+	RawLog    string `json:"raw_log"`
+	Logs      []struct {
 		MsgIndex int    `json:"msg_index"`
 		Success  bool   `json:"success"`
 		Log      string `json:"log"`
@@ -0,0 +1,171 @@
+package rebalancer
+
+import (
+	"sort"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+)
+
+// Plan represents the actions that keep the apps serving the relays they need
+type Plan struct {
+	Actions []*Action `json:"actions"`
+	// Skipped has the reason of every app left as it is that needs an action, keyed by address
+	Skipped map[string]string `json:"skipped,omitempty"`
+	// Unassigned are the chains with demand that no app could take
+	Unassigned []string `json:"unassigned,omitempty"`
+	// DryRun is set when the actions were not submitted
+	DryRun bool `json:"dry_run"`
+}
+
+// Action represents a stake of an app, staking it for the first time, topping it up or changing its chains
+type Action struct {
+	Address string `json:"address"`
+	Reason  string `json:"reason"`
+	// Current is the app before the action, nil when it is not staked
+	Current   *provider.App `json:"current,omitempty"`
+	Chains    []string      `json:"chains"`
+	Stake     int64         `json:"stake"`
+	MaxRelays int64         `json:"max_relays"`
+	// Hash is set once the transaction is submitted
+	Hash   string `json:"hash,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Err    error  `json:"-"`
+	signer transactionbuilder.Signer
+}
+
+// appState represents a managed app and the chains it should serve
+type appState struct {
+	config  App
+	current *provider.App
+	// chains are the chains the app should serve, sorted
+	chains []string
+	// demand is the highest demand of the chains
+	demand int64
+}
+
+func (s *appState) add(chain string, demand int64) {
+	s.chains = append(s.chains, chain)
+	sort.Strings(s.chains)
+
+	if demand > s.demand {
+		s.demand = demand
+	}
+}
+
+// need returns the max relays the app needs, its chains split them evenly so every chain gets its demand
+func (s *appState) need(minRelays int64) int64 {
+	need := int64(len(s.chains)) * s.demand
+	if need < minRelays {
+		return minRelays
+	}
+
+	return need
+}
+
+// stakedFor returns whether the app is currently staked for the chain
+func (s *appState) stakedFor(chain string) bool {
+	if s.current == nil {
+		return false
+	}
+
+	for _, currentChain := range s.current.Chains {
+		if currentChain == chain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// assignChains spreads the chains with demand not served by apps with fixed chains over the other apps
+// The chains with most demand go first, each to the app needing the least relays after taking it,
+// preferring the app already staked for it, returns the chains no app could take
+func assignChains(states []*appState, demand map[string]int64, maximumChains int) []string {
+	fixed := map[string]bool{}
+
+	var auto []*appState
+
+	for _, state := range states {
+		if len(state.config.Chains) == 0 {
+			auto = append(auto, state)
+			continue
+		}
+
+		for _, chain := range state.config.Chains {
+			fixed[chain] = true
+		}
+	}
+
+	chains := make([]string, 0, len(demand))
+
+	for chain, chainDemand := range demand {
+		if chainDemand > 0 && !fixed[chain] {
+			chains = append(chains, chain)
+		}
+	}
+
+	sort.Slice(chains, func(i, j int) bool {
+		if demand[chains[i]] != demand[chains[j]] {
+			return demand[chains[i]] > demand[chains[j]]
+		}
+
+		return chains[i] < chains[j]
+	})
+
+	var unassigned []string
+
+	for _, chain := range chains {
+		chainDemand := demand[chain]
+
+		var best *appState
+
+		var bestNeed int64
+
+		for _, state := range auto {
+			if maximumChains > 0 && len(state.chains) >= maximumChains {
+				continue
+			}
+
+			need := int64(len(state.chains)+1) * maxInt64(state.demand, chainDemand)
+
+			if best == nil || need < bestNeed || (need == bestNeed && state.stakedFor(chain) && !best.stakedFor(chain)) {
+				best, bestNeed = state, need
+			}
+		}
+
+		if best == nil {
+			unassigned = append(unassigned, chain)
+			continue
+		}
+
+		best.add(chain, chainDemand)
+	}
+
+	return unassigned
+}
+
+func sameChains(current, chains []string) bool {
+	if len(current) != len(chains) {
+		return false
+	}
+
+	sorted := append([]string(nil), current...)
+	sort.Strings(sorted)
+
+	for i := range sorted {
+		if sorted[i] != chains[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
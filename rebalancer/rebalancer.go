@@ -0,0 +1,515 @@
+// Package rebalancer keeps a set of apps staked for the relays and chains they need, topping up their stake
+// when their max relays drop and moving chains between them as the demand shifts
+// Underneath uses the packages Provider and TransactionBuilder
+package rebalancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+	"github.com/pokt-foundation/pocket-go/utils"
+)
+
+const (
+	defaultInterval      = 15 * time.Minute
+	defaultPendingBlocks = 5
+
+	maximumChainsParam = "application/MaximumChains"
+)
+
+var (
+	// ErrNoProvider error when no provider is provided
+	ErrNoProvider = errors.New("no provider provided")
+	// ErrNoApps error when no apps are provided
+	ErrNoApps = errors.New("no apps provided")
+	// ErrNoThreshold error when neither min relays nor demand are provided
+	ErrNoThreshold = errors.New("no min relays nor demand provided")
+)
+
+// Provider interface representing provider functions necessary for Rebalancer Package
+type Provider interface {
+	SendTransactionWithCtx(ctx context.Context, input *provider.SendTransactionInput) (*provider.SendTransactionOutput, error)
+	GetTransactionWithCtx(ctx context.Context, transactionHash string, options *provider.GetTransactionOptions) (*provider.GetTransactionOutput, error)
+	GetBlockHeightWithCtx(ctx context.Context) (int, error)
+	GetAllParamsWithCtx(ctx context.Context, options *provider.GetAllParamsOptions) (*provider.AllParams, error)
+	GetAppWithCtx(ctx context.Context, address string, options *provider.GetAppOptions) (*provider.GetAppOutput, error)
+}
+
+// App represents an app managed by the Rebalancer
+type App struct {
+	// Signer is the key of the app, it signs its stakes
+	Signer transactionbuilder.Signer
+	// Chains are the chains the app is staked for, when empty they are assigned from the demand
+	Chains []string
+}
+
+// DemandFunc returns the relays per session needed by chain, i.e. from the gateway metrics
+type DemandFunc func(ctx context.Context) (map[string]int64, error)
+
+// Config represents the configuration of a Rebalancer
+type Config struct {
+	Provider Provider
+	ChainID  transactionbuilder.ChainID
+	Apps     []App
+	// MinRelays is the max relays per session under which an app is topped up
+	MinRelays int64
+	// TargetRelays is the max relays per session apps are topped up to, defaults to MinRelays
+	TargetRelays int64
+	// Demand makes every app serve the demand of each of its chains and assigns the chains of the apps without
+	// fixed chains, optional
+	Demand DemandFunc
+	// ParticipationRate is needed when the network has the participation rate on, see AppStakeCalculator
+	ParticipationRate float64
+	// Fee of every transaction, defaults to the one required by the network
+	Fee int64
+	// DryRun plans the actions without submitting them
+	DryRun bool
+	// PendingBlocks is the amount of blocks an app is left as it is waiting for its last action, defaults to 5
+	PendingBlocks int
+	// Interval is the time between the rebalances of Run, defaults to 15 minutes
+	Interval time.Duration
+	// OnPlan is called with the plan of every rebalance of Run
+	OnPlan func(plan *Plan)
+	// OnError is called with every error of Run
+	OnError func(err error)
+}
+
+// Rebalancer stakes, tops up and moves the chains of a set of apps so they serve the relays they need
+// Apps are staked with their own signer, an app is not planned again until its last action is included
+type Rebalancer struct {
+	provider      Provider
+	chainID       transactionbuilder.ChainID
+	apps          []App
+	minRelays     int64
+	targetRelays  int64
+	demand        DemandFunc
+	fee           int64
+	dryRun        bool
+	pendingBlocks int
+	interval      time.Duration
+	onPlan        func(plan *Plan)
+	onError       func(err error)
+	calculator    *transactionbuilder.AppStakeCalculator
+	feeSchedule   *transactionbuilder.FeeSchedule
+	mu            sync.Mutex
+	pending       map[string]pendingAction
+}
+
+// pendingAction represents a submitted action not included yet
+type pendingAction struct {
+	hash   string
+	height int
+}
+
+// NewRebalancer returns an instance of Rebalancer from the given config
+func NewRebalancer(config Config) (*Rebalancer, error) {
+	if config.Provider == nil {
+		return nil, ErrNoProvider
+	}
+
+	if config.ChainID == "" {
+		return nil, transactionbuilder.ErrNoChainID
+	}
+
+	if len(config.Apps) == 0 {
+		return nil, ErrNoApps
+	}
+
+	for _, app := range config.Apps {
+		if app.Signer == nil {
+			return nil, transactionbuilder.ErrNoSigner
+		}
+	}
+
+	if config.MinRelays <= 0 && config.Demand == nil {
+		return nil, ErrNoThreshold
+	}
+
+	rebalancer := &Rebalancer{
+		provider:      config.Provider,
+		chainID:       config.ChainID,
+		apps:          config.Apps,
+		minRelays:     config.MinRelays,
+		targetRelays:  config.TargetRelays,
+		demand:        config.Demand,
+		fee:           config.Fee,
+		dryRun:        config.DryRun,
+		pendingBlocks: config.PendingBlocks,
+		interval:      config.Interval,
+		onPlan:        config.OnPlan,
+		onError:       config.OnError,
+		calculator:    transactionbuilder.NewAppStakeCalculator(config.Provider),
+		feeSchedule:   transactionbuilder.NewFeeSchedule(config.Provider),
+		pending:       map[string]pendingAction{},
+	}
+
+	if config.ParticipationRate != 0 {
+		rebalancer.calculator.SetParticipationRate(config.ParticipationRate)
+	}
+
+	if rebalancer.targetRelays < rebalancer.minRelays {
+		rebalancer.targetRelays = rebalancer.minRelays
+	}
+
+	if rebalancer.pendingBlocks <= 0 {
+		rebalancer.pendingBlocks = defaultPendingBlocks
+	}
+
+	if rebalancer.interval <= 0 {
+		rebalancer.interval = defaultInterval
+	}
+
+	return rebalancer, nil
+}
+
+// Plan returns the actions the apps need without submitting them
+func (r *Rebalancer) Plan() (*Plan, error) {
+	return r.PlanWithCtx(context.Background())
+}
+
+// PlanWithCtx returns the actions the apps need without submitting them
+func (r *Rebalancer) PlanWithCtx(ctx context.Context) (*Plan, error) {
+	plan, _, err := r.plan(ctx)
+
+	return plan, err
+}
+
+// plan returns the actions the apps need and the height they were planned at
+func (r *Rebalancer) plan(ctx context.Context) (*Plan, int, error) {
+	height, err := r.provider.GetBlockHeightWithCtx(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var demand map[string]int64
+
+	if r.demand != nil {
+		demand, err = r.demand(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	states := make([]*appState, len(r.apps))
+
+	for i, app := range r.apps {
+		current, err := r.getApp(ctx, app.Signer.GetAddress())
+		if err != nil {
+			return nil, 0, err
+		}
+
+		states[i] = &appState{config: app, current: current}
+	}
+
+	plan := &Plan{Skipped: map[string]string{}, DryRun: r.dryRun}
+
+	if demand != nil {
+		params, err := r.provider.GetAllParamsWithCtx(ctx, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		maximumChains, _ := strconv.Atoi(getParam(params.AppParams, maximumChainsParam))
+
+		plan.Unassigned = assignChains(states, demand, maximumChains)
+	}
+
+	for _, state := range states {
+		setChains(state, demand)
+
+		address := state.config.Signer.GetAddress()
+
+		if len(state.chains) == 0 && state.current == nil {
+			plan.Skipped[address] = "no chains to stake for"
+			continue
+		}
+
+		reason := r.reason(state)
+		if reason == "" {
+			continue
+		}
+
+		skipReason, err := r.skipReason(ctx, state, height)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if skipReason != "" {
+			plan.Skipped[address] = skipReason
+			continue
+		}
+
+		action, err := r.newAction(ctx, state, reason)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		plan.Actions = append(plan.Actions, action)
+	}
+
+	return plan, height, nil
+}
+
+// Rebalance submits the actions the apps need, in dry run only plans them
+// Errors submitting an action are set in the action
+func (r *Rebalancer) Rebalance() (*Plan, error) {
+	return r.RebalanceWithCtx(context.Background())
+}
+
+// RebalanceWithCtx submits the actions the apps need, in dry run only plans them
+// Errors submitting an action are set in the action
+func (r *Rebalancer) RebalanceWithCtx(ctx context.Context) (*Plan, error) {
+	plan, height, err := r.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.dryRun {
+		return plan, nil
+	}
+
+	for _, action := range plan.Actions {
+		r.submit(ctx, action, height)
+	}
+
+	return plan, nil
+}
+
+// Run rebalances every interval until the context is done, returning the context error
+func (r *Rebalancer) Run(ctx context.Context) error {
+	for {
+		plan, err := r.RebalanceWithCtx(ctx)
+
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			r.reportError(err)
+		case r.onPlan != nil:
+			r.onPlan(plan)
+		}
+
+		if err := utils.Sleep(ctx, r.interval); err != nil {
+			return err
+		}
+	}
+}
+
+// setChains sets the chains of the apps not assigned by the demand, apps without chains keep the current ones
+func setChains(state *appState, demand map[string]int64) {
+	if len(state.config.Chains) > 0 {
+		state.chains = nil
+
+		for _, chain := range state.config.Chains {
+			state.add(chain, demand[chain])
+		}
+
+		return
+	}
+
+	if len(state.chains) > 0 || state.current == nil {
+		return
+	}
+
+	for _, chain := range state.current.Chains {
+		state.add(chain, demand[chain])
+	}
+}
+
+// reason returns why the app needs an action, empty when it does not
+func (r *Rebalancer) reason(state *appState) string {
+	if len(state.chains) == 0 {
+		return ""
+	}
+
+	if state.current == nil {
+		return "not staked"
+	}
+
+	if need := state.need(r.minRelays); currentRelays(state.current) < need {
+		return fmt.Sprintf("max relays %s below %d", state.current.MaxRelays, need)
+	}
+
+	if !sameChains(state.current.Chains, state.chains) {
+		return fmt.Sprintf("chains change from %v", state.current.Chains)
+	}
+
+	return ""
+}
+
+// skipReason returns why an app needing an action can't have it yet, empty when it can
+func (r *Rebalancer) skipReason(ctx context.Context, state *appState, height int) (string, error) {
+	address := state.config.Signer.GetAddress()
+
+	reason, err := r.checkPending(ctx, address, height)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case reason != "":
+		return reason, nil
+	case state.current == nil:
+		return "", nil
+	case state.current.Jailed:
+		return "jailed", nil
+	case state.current.Status == int(provider.Unstaking):
+		return "unstaking", nil
+	default:
+		return "", nil
+	}
+}
+
+// checkPending returns why the last action of the app keeps it from a new one, empty when it does not
+// A pending action rejected on chain is reported once and forgotten so the next plan retries it
+func (r *Rebalancer) checkPending(ctx context.Context, address string, height int) (string, error) {
+	r.mu.Lock()
+	pending, ok := r.pending[address]
+	r.mu.Unlock()
+
+	if !ok {
+		return "", nil
+	}
+
+	output, err := r.provider.GetTransactionWithCtx(ctx, pending.hash, nil)
+
+	var rpcErr *provider.RPCError
+	if err != nil && !errors.As(err, &rpcErr) {
+		return "", err
+	}
+
+	if err == nil && output.Transaction != nil {
+		r.forgetPending(address)
+
+		_, err = transactionbuilder.CheckTransactionResult(output.Transaction)
+		if err != nil {
+			return err.Error(), nil
+		}
+
+		return "", nil
+	}
+
+	if height >= pending.height+r.pendingBlocks {
+		r.forgetPending(address)
+
+		return "", nil
+	}
+
+	return "waiting for transaction " + pending.hash, nil
+}
+
+func (r *Rebalancer) forgetPending(address string) {
+	r.mu.Lock()
+	delete(r.pending, address)
+	r.mu.Unlock()
+}
+
+// newAction returns the stake of the app for its chains, topped up when its max relays are below its need
+// Stakes never go lower than the current one
+func (r *Rebalancer) newAction(ctx context.Context, state *appState, reason string) (*Action, error) {
+	stake := new(big.Int)
+
+	if state.current != nil {
+		stake.SetString(state.current.StakedTokens, 10)
+	}
+
+	need := state.need(r.minRelays)
+
+	if currentRelays(state.current) < need {
+		required, err := r.calculator.RequiredStakeWithCtx(ctx, maxInt64(r.targetRelays, need))
+		if err != nil {
+			return nil, err
+		}
+
+		if big.NewInt(required).Cmp(stake) > 0 {
+			stake.SetInt64(required)
+		}
+	}
+
+	maxRelays, err := r.calculator.MaxRelaysWithCtx(ctx, stake.Int64())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Action{
+		Address:   state.config.Signer.GetAddress(),
+		Reason:    reason,
+		Current:   state.current,
+		Chains:    state.chains,
+		Stake:     stake.Int64(),
+		MaxRelays: maxRelays,
+		signer:    state.config.Signer,
+	}, nil
+}
+
+func (r *Rebalancer) submit(ctx context.Context, action *Action, height int) {
+	txMsg, err := transactionbuilder.NewStakeApp(action.signer.GetPublicKey(), action.Chains, action.Stake)
+	if err != nil {
+		action.setError(err)
+		return
+	}
+
+	txBuilder := transactionbuilder.NewTransactionBuilder(r.provider, action.signer)
+	txBuilder.SetFeeCalculator(r.feeSchedule)
+
+	output, err := txBuilder.SubmitWithCtx(ctx, r.chainID, txMsg, &transactionbuilder.TransactionOptions{Fee: r.fee})
+	if err != nil {
+		action.setError(err)
+		return
+	}
+
+	action.Hash = output.Txhash
+
+	r.mu.Lock()
+	r.pending[action.Address] = pendingAction{hash: output.Txhash, height: height}
+	r.mu.Unlock()
+}
+
+func (a *Action) setError(err error) {
+	a.Err = err
+	a.Error = err.Error()
+}
+
+// getApp returns the app with the address, nil when it is not staked
+func (r *Rebalancer) getApp(ctx context.Context, address string) (*provider.App, error) {
+	output, err := r.provider.GetAppWithCtx(ctx, address, nil)
+	if provider.IsNotFoundError(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output.App, nil
+}
+
+func (r *Rebalancer) reportError(err error) {
+	if r.onError != nil {
+		r.onError(err)
+	}
+}
+
+// currentRelays returns the max relays of the app, zero when it is not staked
+func currentRelays(app *provider.App) int64 {
+	if app == nil {
+		return 0
+	}
+
+	relays, _ := strconv.ParseInt(app.MaxRelays, 10, 64)
+
+	return relays
+}
+
+func getParam(params provider.ParamGroup, key string) string {
+	value, _ := params.Get(key)
+
+	return value
+}
@@ -0,0 +1,230 @@
+package rebalancer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebalancer_Rebalance(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	for _, chain := range []string{"0001", "0002", "0003", "0004", "0005"} {
+		server.SetChainBackend(chain, http.NotFoundHandler())
+	}
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+
+	signers := make([]*signer.Signer, 3)
+
+	for i := range signers {
+		var err error
+
+		signers[i], err = signer.NewRandomSigner()
+		c.NoError(err)
+
+		server.SetBalance(signers[i].GetAddress(), 10000000)
+	}
+
+	fixedApp, newApp, stakedApp := signers[0], signers[1], signers[2]
+
+	c.NoError(server.AddApp(provider.App{PublicKey: fixedApp.GetPublicKey(), Chains: []string{"0001"}, StakedTokens: "1000000"}))
+	c.NoError(server.AddApp(provider.App{PublicKey: stakedApp.GetPublicKey(), Chains: []string{"0002"}, StakedTokens: "10000000"}))
+
+	demand := map[string]int64{"0001": 5000, "0002": 3000, "0003": 1000}
+
+	config := Config{
+		Provider: rpcProvider,
+		ChainID:  transactionbuilder.Localnet,
+		Apps: []App{
+			{Signer: fixedApp, Chains: []string{"0001"}},
+			{Signer: newApp},
+			{Signer: stakedApp},
+		},
+		MinRelays: 1000,
+		Demand: func(ctx context.Context) (map[string]int64, error) {
+			return demand, nil
+		},
+		DryRun: true,
+	}
+
+	dryRun, err := NewRebalancer(config)
+	c.NoError(err)
+
+	plan, err := dryRun.Rebalance()
+	c.NoError(err)
+	c.True(plan.DryRun)
+	c.Empty(plan.Skipped)
+	c.Len(plan.Actions, 2)
+
+	// Every chain gets its demand from the even split of the max relays
+	c.Equal(fixedApp.GetAddress(), plan.Actions[0].Address)
+	c.Equal("max relays 2000 below 5000", plan.Actions[0].Reason)
+	c.Equal([]string{"0001"}, plan.Actions[0].Chains)
+	c.Equal(int64(2500000), plan.Actions[0].Stake)
+	c.Equal(int64(5000), plan.Actions[0].MaxRelays)
+	c.Empty(plan.Actions[0].Hash)
+
+	// The app already staked for a chain keeps it, the new app takes the other one at the stake minimum
+	c.Equal(newApp.GetAddress(), plan.Actions[1].Address)
+	c.Equal("not staked", plan.Actions[1].Reason)
+	c.Nil(plan.Actions[1].Current)
+	c.Equal([]string{"0003"}, plan.Actions[1].Chains)
+	c.Equal(int64(1000000), plan.Actions[1].Stake)
+
+	server.Commit()
+	c.Equal(int64(10000000), server.Balance(fixedApp.GetAddress()).Int64())
+
+	config.DryRun = false
+
+	rebalancer, err := NewRebalancer(config)
+	c.NoError(err)
+
+	plan, err = rebalancer.Rebalance()
+	c.NoError(err)
+	c.Len(plan.Actions, 2)
+	c.NotEmpty(plan.Actions[0].Hash)
+	c.NotEmpty(plan.Actions[1].Hash)
+
+	hash := plan.Actions[0].Hash
+
+	// Apps are not planned again until their actions are included
+	plan, err = rebalancer.Plan()
+	c.NoError(err)
+	c.Empty(plan.Actions)
+	c.Equal("waiting for transaction "+hash, plan.Skipped[fixedApp.GetAddress()])
+
+	server.Commit()
+
+	plan, err = rebalancer.Plan()
+	c.NoError(err)
+	c.Empty(plan.Actions)
+	c.Empty(plan.Skipped)
+
+	app, err := rpcProvider.GetApp(fixedApp.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal("2500000", app.StakedTokens)
+	c.Equal("5000", app.MaxRelays)
+
+	// Chains without demand are moved to the new ones without lowering the stake
+	demand = map[string]int64{"0001": 5000, "0002": 3000, "0004": 1000}
+
+	plan, err = rebalancer.Rebalance()
+	c.NoError(err)
+	c.Len(plan.Actions, 1)
+	c.Equal("chains change from [0003]", plan.Actions[0].Reason)
+	c.Equal([]string{"0004"}, plan.Actions[0].Chains)
+	c.Equal(int64(1000000), plan.Actions[0].Stake)
+
+	server.Commit()
+
+	app, err = rpcProvider.GetApp(newApp.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal([]string{"0004"}, app.Chains)
+
+	// Chains over the maximum of every app are reported
+	server.SetParam("application/MaximumChains", "1")
+	demand["0005"] = 500
+
+	plan, err = rebalancer.Plan()
+	c.NoError(err)
+	c.Empty(plan.Actions)
+	c.Equal([]string{"0005"}, plan.Unassigned)
+
+	// Stakes rejected on chain are reported once, then planned again
+	server.SetBalance(fixedApp.GetAddress(), 100000)
+	demand["0001"] = 6000
+
+	plan, err = rebalancer.Rebalance()
+	c.NoError(err)
+	c.Len(plan.Actions, 1)
+	c.NoError(plan.Actions[0].Err)
+
+	hash = plan.Actions[0].Hash
+
+	server.Commit()
+
+	plan, err = rebalancer.Plan()
+	c.NoError(err)
+	c.Empty(plan.Actions)
+	c.Contains(plan.Skipped[fixedApp.GetAddress()], "transaction "+hash+" failed")
+
+	plan, err = rebalancer.Plan()
+	c.NoError(err)
+	c.Len(plan.Actions, 1)
+
+	// Submission errors are kept in the action
+	server.SetBalance(fixedApp.GetAddress(), 0)
+
+	plan, err = rebalancer.Rebalance()
+	c.NoError(err)
+	c.Len(plan.Actions, 1)
+	c.ErrorIs(plan.Actions[0].Err, transactionbuilder.ErrInsufficientFunds)
+	c.NotEmpty(plan.Actions[0].Error)
+
+	c.NoError(server.AddApp(provider.App{PublicKey: fixedApp.GetPublicKey(), Chains: []string{"0001"}, StakedTokens: "2500000", Jailed: true}))
+
+	plan, err = rebalancer.Plan()
+	c.NoError(err)
+	c.Empty(plan.Actions)
+	c.Equal("jailed", plan.Skipped[fixedApp.GetAddress()])
+
+	_, err = NewRebalancer(Config{Provider: rpcProvider, ChainID: transactionbuilder.Localnet, Apps: config.Apps})
+	c.Equal(ErrNoThreshold, err)
+
+	_, err = NewRebalancer(Config{Provider: rpcProvider, ChainID: transactionbuilder.Localnet, MinRelays: 1})
+	c.Equal(ErrNoApps, err)
+}
+
+func TestRebalancer_Run(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{AutoCommit: true})
+	defer server.Close()
+
+	server.SetChainBackend("0001", http.NotFoundHandler())
+
+	appSigner, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(appSigner.GetAddress(), 10000000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var plans []*Plan
+
+	rebalancer, err := NewRebalancer(Config{
+		Provider:     provider.NewProvider(server.URL, []string{server.URL}),
+		ChainID:      transactionbuilder.Localnet,
+		Apps:         []App{{Signer: appSigner, Chains: []string{"0001"}}},
+		MinRelays:    3000,
+		TargetRelays: 4000,
+		Interval:     time.Millisecond,
+		OnPlan: func(plan *Plan) {
+			plans = append(plans, plan)
+
+			if len(plans) == 2 {
+				cancel()
+			}
+		},
+	})
+	c.NoError(err)
+
+	c.Equal(context.Canceled, rebalancer.Run(ctx))
+	c.Len(plans, 2)
+	c.Len(plans[0].Actions, 1)
+	c.Equal(int64(4000), plans[0].Actions[0].MaxRelays)
+	c.Empty(plans[1].Actions)
+	c.Empty(plans[1].Skipped)
+}
@@ -0,0 +1,228 @@
+// Package relaychain is a registry of the relay chains of Pocket with their metadata
+// Relay chains are identified by 4 hex characters like 0021, the registry gives them names and relay defaults
+package relaychain
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+// Protocol represents the kind of API a relay chain serves
+type Protocol string
+
+const (
+	// JSONRPC represents chains receiving JSON-RPC calls as the relay data, i.e. EVM chains
+	JSONRPC Protocol = "json-rpc"
+	// REST represents chains receiving requests to paths, i.e. /v1/query/height for Pocket
+	REST Protocol = "rest"
+)
+
+// chainIDLength is the length in bytes of a relay chain ID, pocket-core rejects other lengths
+const chainIDLength = 2
+
+var (
+	// ErrInvalidChainID error when the chain ID is not 4 hex characters
+	ErrInvalidChainID = errors.New("invalid chain id")
+	// ErrUnknownProtocol error when the chain protocol is not JSONRPC nor REST
+	ErrUnknownProtocol = errors.New("unknown protocol")
+	// ErrDuplicateChain error when the chain is already in the registry
+	ErrDuplicateChain = errors.New("duplicate chain")
+	// ErrUnknownChain error when the chain is not in the registry
+	ErrUnknownChain = errors.New("unknown chain")
+)
+
+// UnsupportedChainsError is returned when registry chains are not supported by the network
+type UnsupportedChainsError struct {
+	Chains []string
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *UnsupportedChainsError) Error() string {
+	return fmt.Sprintf("chains not supported by the network: %s", strings.Join(e.Chains, ", "))
+}
+
+// Provider interface representing provider functions necessary for Relay Chain Package
+type Provider interface {
+	GetSupportedChainsWithCtx(ctx context.Context, options *provider.GetSupportedChainsOptions) ([]string, error)
+}
+
+// HealthCheck represents the relay used to check that a node serves a chain
+type HealthCheck struct {
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+	Path   string `json:"path,omitempty" yaml:"path,omitempty"`
+	Data   string `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// Chain represents a relay chain and its metadata
+type Chain struct {
+	ID       string   `json:"id" yaml:"id"`
+	Name     string   `json:"name" yaml:"name"`
+	Protocol Protocol `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	// Path is the default path of the relays to the chain, used when a relay has no path
+	Path        string       `json:"path,omitempty" yaml:"path,omitempty"`
+	HealthCheck *HealthCheck `json:"health_check,omitempty" yaml:"health_check,omitempty"`
+}
+
+// Validate returns the error of the chain metadata if any
+func (c *Chain) Validate() error {
+	decodedID, err := hex.DecodeString(c.ID)
+	if err != nil || len(decodedID) != chainIDLength {
+		return fmt.Errorf("%w: %q", ErrInvalidChainID, c.ID)
+	}
+
+	if c.Protocol != "" && c.Protocol != JSONRPC && c.Protocol != REST {
+		return fmt.Errorf("%w: %q of chain %s", ErrUnknownProtocol, c.Protocol, c.ID)
+	}
+
+	return nil
+}
+
+// registryFile represents the file format read by LoadRegistry
+type registryFile struct {
+	Chains []Chain `json:"chains" yaml:"chains"`
+}
+
+// Registry represents a set of relay chains with their metadata
+// It is safe for concurrent use
+type Registry struct {
+	mu     sync.RWMutex
+	chains map[string]Chain
+}
+
+// NewRegistry returns an instance of Registry with the given chains
+// Chains without protocol are JSONRPC
+func NewRegistry(chains ...Chain) (*Registry, error) {
+	registry := &Registry{chains: map[string]Chain{}}
+
+	for _, chain := range chains {
+		if err := registry.Add(chain); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// LoadRegistry returns a Registry with the chains of a JSON or YAML file, picked by the file extension
+// The file holds a chains list, i.e. {"chains": [{"id": "0021", "name": "Ethereum Mainnet"}]}
+func LoadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file registryFile
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRegistry(file.Chains...)
+}
+
+// Add adds a chain to the registry
+func (r *Registry) Add(chain Chain) error {
+	if err := chain.Validate(); err != nil {
+		return err
+	}
+
+	if chain.Protocol == "" {
+		chain.Protocol = JSONRPC
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.chains[chain.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateChain, chain.ID)
+	}
+
+	r.chains[chain.ID] = chain
+
+	return nil
+}
+
+// Get returns the chain with the ID
+func (r *Registry) Get(id string) (Chain, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chain, ok := r.chains[id]
+
+	return chain, ok
+}
+
+// Chains returns every chain of the registry sorted by ID
+func (r *Registry) Chains() []Chain {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chains := make([]Chain, 0, len(r.chains))
+	for _, chain := range r.chains {
+		chains = append(chains, chain)
+	}
+
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ID < chains[j].ID })
+
+	return chains
+}
+
+// ValidateChains returns ErrUnknownChain for the first chain not in the registry
+func (r *Registry) ValidateChains(chains []string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, chain := range chains {
+		if _, ok := r.chains[chain]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownChain, chain)
+		}
+	}
+
+	return nil
+}
+
+// ValidateSupported returns UnsupportedChainsError when chains of the registry are not supported by the network
+func (r *Registry) ValidateSupported(ctx context.Context, rpcProvider Provider) error {
+	supportedChains, err := rpcProvider.GetSupportedChainsWithCtx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	supported := make(map[string]bool, len(supportedChains))
+	for _, chain := range supportedChains {
+		supported[chain] = true
+	}
+
+	var unsupported []string
+
+	for _, chain := range r.Chains() {
+		if !supported[chain.ID] {
+			unsupported = append(unsupported, chain.ID)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return &UnsupportedChainsError{Chains: unsupported}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package relaychain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/utils-go/mock-client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistry(t *testing.T) {
+	c := require.New(t)
+
+	registry, err := NewRegistry(
+		Chain{ID: "0021", Name: "Ethereum Mainnet"},
+		Chain{ID: "0001", Name: "Pocket Network Mainnet", Protocol: REST, Path: "/v1/query/height"},
+	)
+	c.NoError(err)
+
+	chain, ok := registry.Get("0021")
+	c.True(ok)
+	c.Equal(JSONRPC, chain.Protocol)
+
+	_, ok = registry.Get("0009")
+	c.False(ok)
+
+	chains := registry.Chains()
+	c.Len(chains, 2)
+	c.Equal("0001", chains[0].ID)
+
+	c.NoError(registry.ValidateChains([]string{"0001", "0021"}))
+	c.ErrorIs(registry.ValidateChains([]string{"0021", "0009"}), ErrUnknownChain)
+
+	c.ErrorIs(registry.Add(Chain{ID: "0021"}), ErrDuplicateChain)
+	c.ErrorIs(registry.Add(Chain{ID: "21"}), ErrInvalidChainID)
+	c.ErrorIs(registry.Add(Chain{ID: "00zz"}), ErrInvalidChainID)
+	c.ErrorIs(registry.Add(Chain{ID: "0009", Protocol: "grpc"}), ErrUnknownProtocol)
+
+	_, err = NewRegistry(Chain{ID: "0021"}, Chain{ID: "0021"})
+	c.ErrorIs(err, ErrDuplicateChain)
+}
+
+func TestLoadRegistry(t *testing.T) {
+	c := require.New(t)
+
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "chains.json")
+	c.NoError(os.WriteFile(jsonPath, []byte(`{"chains": [
+		{"id": "0021", "name": "Ethereum Mainnet", "health_check": {"data": "{\"jsonrpc\":\"2.0\",\"method\":\"eth_blockNumber\",\"params\":[],\"id\":1}"}}
+	]}`), 0o600))
+
+	registry, err := LoadRegistry(jsonPath)
+	c.NoError(err)
+
+	chain, ok := registry.Get("0021")
+	c.True(ok)
+	c.Equal("Ethereum Mainnet", chain.Name)
+	c.Equal(`{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`, chain.HealthCheck.Data)
+
+	yamlPath := filepath.Join(dir, "chains.yaml")
+	c.NoError(os.WriteFile(yamlPath, []byte(`chains:
+  - id: "0001"
+    name: Pocket Network Mainnet
+    protocol: rest
+    health_check:
+      method: POST
+      path: /v1/query/height
+`), 0o600))
+
+	registry, err = LoadRegistry(yamlPath)
+	c.NoError(err)
+
+	chain, ok = registry.Get("0001")
+	c.True(ok)
+	c.Equal(REST, chain.Protocol)
+	c.Equal("/v1/query/height", chain.HealthCheck.Path)
+
+	c.NoError(os.WriteFile(yamlPath, []byte("chains:\n  - id: 1\n"), 0o600))
+
+	_, err = LoadRegistry(yamlPath)
+	c.ErrorIs(err, ErrInvalidChainID)
+
+	_, err = LoadRegistry(filepath.Join(dir, "missing.yaml"))
+	c.Error(err)
+}
+
+func TestRegistry_ValidateSupported(t *testing.T) {
+	c := require.New(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	rpcProvider := provider.NewProvider("https://dummy.com", []string{"https://dummy.com"})
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", provider.QuerySupportedChainsRoute),
+		http.StatusOK, "../provider/samples/query_supportedchains.json")
+
+	registry, err := NewRegistry(Chain{ID: "0001"}, Chain{ID: "0021"})
+	c.NoError(err)
+
+	c.NoError(registry.ValidateSupported(context.Background(), rpcProvider))
+
+	c.NoError(registry.Add(Chain{ID: "0040"}))
+	c.NoError(registry.Add(Chain{ID: "0027"}))
+
+	err = registry.ValidateSupported(context.Background(), rpcProvider)
+
+	var unsupportedErr *UnsupportedChainsError
+	c.ErrorAs(err, &unsupportedErr)
+	c.Equal([]string{"0027", "0040"}, unsupportedErr.Chains)
+	c.Equal("chains not supported by the network: 0027, 0040", err.Error())
+}
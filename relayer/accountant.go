@@ -0,0 +1,252 @@
+package relayer
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+var (
+	// ErrNodeRelaysExhausted error when the node used its relay allowance for the session
+	ErrNodeRelaysExhausted = errors.New("node relay allowance exhausted for session")
+	// ErrSessionRelaysExhausted error when every node of the session used its relay allowance
+	ErrSessionRelaysExhausted = errors.New("session relay allowance exhausted")
+	// ErrAppNotInSession error when the app does not match the app of the session
+	ErrAppNotInSession = errors.New("app does not match session app")
+)
+
+// NodeAllowance returns the relays each node of a session can serve to the app
+// pocket-core splits the app max relays evenly across its chains and the session nodes, rounding half to even
+func NodeAllowance(app *provider.App, sessionNodeCount int) (int64, error) {
+	maxRelays, err := strconv.ParseInt(app.MaxRelays, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	chains := int64(len(app.Chains))
+	if chains == 0 {
+		chains = 1
+	}
+
+	divisor := chains * int64(sessionNodeCount)
+	if divisor <= 0 {
+		return maxRelays, nil
+	}
+
+	allowance, remainder := maxRelays/divisor, maxRelays%divisor
+
+	if 2*remainder > divisor || (2*remainder == divisor && allowance%2 == 1) {
+		allowance++
+	}
+
+	return allowance, nil
+}
+
+// SessionUsage represents the relays done to the nodes of a session
+type SessionUsage struct {
+	AppPublicKey  string `json:"app_public_key"`
+	Chain         string `json:"chain"`
+	SessionHeight int    `json:"session_height"`
+	NodeAllowance int64  `json:"node_allowance"`
+	// Relays is keyed by node public key
+	Relays map[string]int64 `json:"relays"`
+}
+
+type sessionBudget struct {
+	allowance int64
+	relays    map[string]int64
+}
+
+// Accountant counts the relays sent to every node of a session against the node allowance
+// Set it in a Relayer with SetAccountant, sessions not added to the Accountant are not accounted
+type Accountant struct {
+	mu       sync.Mutex
+	sessions map[provider.SessionHeader]*sessionBudget
+}
+
+// NewAccountant returns an instance of Accountant
+func NewAccountant() *Accountant {
+	return &Accountant{
+		sessions: map[provider.SessionHeader]*sessionBudget{},
+	}
+}
+
+// AddSession starts accounting the relays of the session, its allowance is computed from the app
+// Adding a session again keeps its relay counts
+func (a *Accountant) AddSession(app *provider.App, session *provider.Session) error {
+	if session == nil {
+		return ErrNoSession
+	}
+
+	if app.PublicKey != session.Header.AppPublicKey {
+		return ErrAppNotInSession
+	}
+
+	allowance, err := NodeAllowance(app, len(session.Nodes))
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if budget, ok := a.sessions[session.Header]; ok {
+		budget.allowance = allowance
+		return nil
+	}
+
+	a.sessions[session.Header] = &sessionBudget{
+		allowance: allowance,
+		relays:    map[string]int64{},
+	}
+
+	return nil
+}
+
+// RemoveSessions stops accounting the sessions below the height, usually the ones that ended
+func (a *Accountant) RemoveSessions(sessionHeight int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for header := range a.sessions {
+		if header.SessionHeight < sessionHeight {
+			delete(a.sessions, header)
+		}
+	}
+}
+
+// Remaining returns the relays the node has left in the session, false when the session is not accounted
+func (a *Accountant) Remaining(session *provider.Session, nodePublicKey string) (int64, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	budget, ok := a.sessions[session.Header]
+	if !ok {
+		return 0, false
+	}
+
+	return budget.allowance - budget.relays[nodePublicKey], true
+}
+
+// Usage returns the usage of every accounted session, sorted by session height, chain and app
+func (a *Accountant) Usage() []SessionUsage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	usages := make([]SessionUsage, 0, len(a.sessions))
+
+	for header, budget := range a.sessions {
+		relays := make(map[string]int64, len(budget.relays))
+		for node, count := range budget.relays {
+			relays[node] = count
+		}
+
+		usages = append(usages, SessionUsage{
+			AppPublicKey:  header.AppPublicKey,
+			Chain:         header.Chain,
+			SessionHeight: header.SessionHeight,
+			NodeAllowance: budget.allowance,
+			Relays:        relays,
+		})
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].SessionHeight != usages[j].SessionHeight {
+			return usages[i].SessionHeight < usages[j].SessionHeight
+		}
+
+		if usages[i].Chain != usages[j].Chain {
+			return usages[i].Chain < usages[j].Chain
+		}
+
+		return usages[i].AppPublicKey < usages[j].AppPublicKey
+	})
+
+	return usages
+}
+
+// reserve counts a relay to the node, picking a random node with relays left when node is nil
+func (a *Accountant) reserve(session *provider.Session, node *provider.Node) (*provider.Node, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	budget, ok := a.sessions[session.Header]
+	if !ok {
+		if node == nil {
+			return GetRandomSessionNode(session)
+		}
+
+		return node, nil
+	}
+
+	if node == nil {
+		var err error
+
+		node, err = budget.randomAvailableNode(session)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if budget.relays[node.PublicKey] >= budget.allowance {
+		return nil, ErrNodeRelaysExhausted
+	}
+
+	budget.relays[node.PublicKey]++
+
+	return node, nil
+}
+
+// exhaust marks the node as out of relays, used when the node reports so before the count reached the allowance
+func (a *Accountant) exhaust(session *provider.Session, node *provider.Node) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if budget, ok := a.sessions[session.Header]; ok {
+		budget.relays[node.PublicKey] = budget.allowance
+	}
+}
+
+// release gives back a relay counted by reserve, used when the relay never reached the node
+func (a *Accountant) release(session *provider.Session, node *provider.Node) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if budget, ok := a.sessions[session.Header]; ok && budget.relays[node.PublicKey] > 0 {
+		budget.relays[node.PublicKey]--
+	}
+}
+
+// isTransportError returns if the request failed before the node responded, i.e. the connection was refused
+func isTransportError(err error) bool {
+	var urlErr *url.Error
+
+	return errors.As(err, &urlErr)
+}
+
+func (b *sessionBudget) randomAvailableNode(session *provider.Session) (*provider.Node, error) {
+	available := make([]provider.Node, 0, len(session.Nodes))
+
+	for _, node := range session.Nodes {
+		if b.relays[node.PublicKey] < b.allowance {
+			available = append(available, node)
+		}
+	}
+
+	if len(available) == 0 {
+		return nil, ErrSessionRelaysExhausted
+	}
+
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(len(available))))
+	if err != nil {
+		return nil, err
+	}
+
+	return &available[index.Int64()], nil
+}
@@ -0,0 +1,171 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+type providerMock struct {
+	mu       sync.Mutex
+	relays   map[string]int
+	inputs   []*provider.RelayInput
+	relayErr error
+}
+
+func (p *providerMock) RelayWithCtx(ctx context.Context, rpcURL string, input *provider.RelayInput, options *provider.RelayRequestOptions) (*provider.RelayOutput, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.relays[input.Proof.ServicerPubKey]++
+	p.inputs = append(p.inputs, input)
+
+	return &provider.RelayOutput{Response: `{"result":"0x1"}`, StatusCode: 200}, p.relayErr
+}
+
+func TestNodeAllowance(t *testing.T) {
+	c := require.New(t)
+
+	tests := []struct {
+		maxRelays string
+		chains    []string
+		nodes     int
+		expected  int64
+	}{
+		{maxRelays: "20000", chains: []string{"0021"}, nodes: 5, expected: 4000},
+		{maxRelays: "20000", chains: []string{"0001", "0021"}, nodes: 24, expected: 417},
+		{maxRelays: "10", chains: []string{"0021"}, nodes: 4, expected: 2},
+		{maxRelays: "14", chains: []string{"0021"}, nodes: 4, expected: 4},
+		{maxRelays: "10", nodes: 0, expected: 10},
+	}
+
+	for _, tt := range tests {
+		allowance, err := NodeAllowance(&provider.App{MaxRelays: tt.maxRelays, Chains: tt.chains}, tt.nodes)
+		c.NoError(err)
+		c.Equal(tt.expected, allowance, tt.maxRelays)
+	}
+
+	_, err := NodeAllowance(&provider.App{MaxRelays: "many"}, 5)
+	c.Error(err)
+}
+
+func TestAccountant_Relay(t *testing.T) {
+	c := require.New(t)
+
+	clientSigner, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	providerMock := &providerMock{relays: map[string]int{}}
+
+	relayer := NewRelayer(clientSigner, providerMock)
+	accountant := NewAccountant()
+	relayer.SetAccountant(accountant)
+
+	app := &provider.App{PublicKey: "app", MaxRelays: "4", Chains: []string{"0021"}}
+	session := &provider.Session{
+		Header: provider.SessionHeader{AppPublicKey: "app", Chain: "0021", SessionHeight: 21},
+		Nodes:  []provider.Node{{PublicKey: "node1"}, {PublicKey: "node2"}},
+	}
+
+	input := &Input{Blockchain: "0021", Session: session, PocketAAT: &provider.PocketAAT{AppPubKey: "app"}}
+
+	c.Equal(ErrAppNotInSession, accountant.AddSession(&provider.App{PublicKey: "other"}, session))
+	c.NoError(accountant.AddSession(app, session))
+
+	// Relays are spread over the nodes with relays left
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := relayer.RelayWithCtx(context.Background(), input, nil)
+			c.NoError(err)
+		}()
+	}
+
+	wg.Wait()
+
+	c.Equal(map[string]int{"node1": 2, "node2": 2}, providerMock.relays)
+
+	_, err = relayer.Relay(input, nil)
+	c.Equal(ErrSessionRelaysExhausted, err)
+
+	input.Node = &provider.Node{PublicKey: "node1"}
+
+	_, err = relayer.Relay(input, nil)
+	c.Equal(ErrNodeRelaysExhausted, err)
+
+	remaining, ok := accountant.Remaining(session, "node1")
+	c.True(ok)
+	c.Zero(remaining)
+
+	usage := accountant.Usage()
+	c.Len(usage, 1)
+	c.Equal(SessionUsage{
+		AppPublicKey:  "app",
+		Chain:         "0021",
+		SessionHeight: 21,
+		NodeAllowance: 2,
+		Relays:        map[string]int64{"node1": 2, "node2": 2},
+	}, usage[0])
+
+	// Nodes rejecting relays are exhausted before reaching the count
+	nextSession := &provider.Session{
+		Header: provider.SessionHeader{AppPublicKey: "app", Chain: "0021", SessionHeight: 25},
+		Nodes:  session.Nodes,
+	}
+
+	c.NoError(accountant.AddSession(app, nextSession))
+	accountant.RemoveSessions(25)
+	c.Len(accountant.Usage(), 1)
+
+	input.Session = nextSession
+	providerMock.relayErr = &provider.RelayError{Code: provider.OverServiceError}
+
+	_, err = relayer.Relay(input, nil)
+	c.True(provider.IsErrorCode(provider.OverServiceError, err))
+
+	remaining, _ = accountant.Remaining(nextSession, "node1")
+	c.Zero(remaining)
+
+	remaining, _ = accountant.Remaining(nextSession, "node2")
+	c.Equal(int64(2), remaining)
+
+	// Relays that never reached the node are given back, the ones the node answered are kept
+	input.Node = &provider.Node{PublicKey: "node2"}
+	providerMock.relayErr = &url.Error{Op: "Post", URL: "https://node2", Err: errors.New("connection refused")}
+
+	_, err = relayer.Relay(input, nil)
+	c.Error(err)
+
+	remaining, _ = accountant.Remaining(nextSession, "node2")
+	c.Equal(int64(2), remaining)
+
+	providerMock.relayErr = provider.Err5xxOnConnection
+
+	_, err = relayer.Relay(input, nil)
+	c.Equal(provider.Err5xxOnConnection, err)
+
+	remaining, _ = accountant.Remaining(nextSession, "node2")
+	c.Equal(int64(1), remaining)
+
+	// Sessions not added are not accounted
+	input.Session = session
+	accountant.RemoveSessions(30)
+	providerMock.relayErr = nil
+
+	_, err = relayer.Relay(input, nil)
+	c.NoError(err)
+
+	_, ok = accountant.Remaining(session, "node1")
+	c.False(ok)
+}
@@ -14,6 +14,7 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relaychain"
 )
 
 var (
@@ -43,10 +44,17 @@ type Signer interface {
 	Sign(payload []byte) (string, error)
 }
 
+// ChainRegistry interface representing chain registry functions necessary for Relayer Package
+type ChainRegistry interface {
+	Get(id string) (relaychain.Chain, bool)
+}
+
 // Relayer implementation of relayer interface
 type Relayer struct {
-	signer   Signer
-	provider Provider
+	signer        Signer
+	provider      Provider
+	accountant    *Accountant
+	chainRegistry ChainRegistry
 }
 
 // NewRelayer returns instance of Relayer with given input.
@@ -60,6 +68,17 @@ func NewRelayer(signer Signer, provider Provider) *Relayer {
 	}
 }
 
+// SetAccountant sets the Accountant checking the relay allowance of the session nodes before every relay
+// Relays without Input.Node go to a random node with relays left, relays to an exhausted node are refused
+func (r *Relayer) SetAccountant(accountant *Accountant) {
+	r.accountant = accountant
+}
+
+// SetChainRegistry sets the registry whose chain defaults fill the relays, i.e. the path of relays without one
+func (r *Relayer) SetChainRegistry(chainRegistry ChainRegistry) {
+	r.chainRegistry = chainRegistry
+}
+
 func (r *Relayer) validateRelayRequest(input *Input) error {
 	if r.signer == nil {
 		return ErrNoSigner
@@ -88,13 +107,17 @@ func (r *Relayer) validateRelayRequest(input *Input) error {
 	return nil
 }
 
-func getNode(input *Input) (*provider.Node, error) {
-	if input.Node == nil {
-		return GetRandomSessionNode(input.Session)
+func (r *Relayer) getNode(input *Input) (*provider.Node, error) {
+	if input.Node != nil && !IsNodeInSession(input.Session, input.Node) {
+		return nil, ErrNodeNotInSession
 	}
 
-	if !IsNodeInSession(input.Session, input.Node) {
-		return nil, ErrNodeNotInSession
+	if r.accountant != nil {
+		return r.accountant.reserve(input.Session, input.Node)
+	}
+
+	if input.Node == nil {
+		return GetRandomSessionNode(input.Session)
 	}
 
 	return input.Node, nil
@@ -122,7 +145,7 @@ func (r *Relayer) buildRelay(
 	relayPayload := &provider.RelayPayload{
 		Data:    input.Data,
 		Method:  input.Method,
-		Path:    input.Path,
+		Path:    r.relayPath(input),
 		Headers: input.Headers,
 	}
 
@@ -171,6 +194,20 @@ func (r *Relayer) buildRelay(
 	}, nil
 }
 
+// relayPath returns the path of the input, defaulting to the path of its chain in the registry
+func (r *Relayer) relayPath(input *Input) string {
+	if input.Path != "" || r.chainRegistry == nil {
+		return input.Path
+	}
+
+	chain, ok := r.chainRegistry.Get(input.Blockchain)
+	if !ok {
+		return ""
+	}
+
+	return chain.Path
+}
+
 // Relay does relay request with given input
 // Will always return with an output that includes the status code from the request
 func (r *Relayer) Relay(input *Input, options *provider.RelayRequestOptions) (*Output, error) {
@@ -191,18 +228,31 @@ func (r *Relayer) RelayWithCtx(ctx context.Context, input *Input, options *provi
 		return defaultOutput, err
 	}
 
-	node, err := getNode(input)
+	node, err := r.getNode(input)
 	if err != nil {
 		return defaultOutput, err
 	}
 
 	relayInput, err := r.buildRelay(node, input, options)
 	if err != nil {
+		if r.accountant != nil {
+			r.accountant.release(input.Session, node)
+		}
+
 		return defaultOutput, err
 	}
 
 	relayOutput, relayErr := r.provider.RelayWithCtx(ctx, node.ServiceURL, relayInput, options)
 	if relayErr != nil {
+		if r.accountant != nil {
+			switch {
+			case provider.IsExhaustionError(relayErr):
+				r.accountant.exhaust(input.Session, node)
+			case isTransportError(relayErr):
+				r.accountant.release(input.Session, node)
+			}
+		}
+
 		defaultOutput.RelayOutput = relayOutput
 		return defaultOutput, relayErr
 	}
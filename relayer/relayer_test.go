@@ -8,6 +8,7 @@ import (
 
 	"github.com/jarcoal/httpmock"
 	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relaychain"
 	"github.com/pokt-foundation/pocket-go/signer"
 	"github.com/pokt-foundation/utils-go/mock-client"
 	"github.com/stretchr/testify/require"
@@ -183,3 +184,45 @@ func TestRelayer_RelayWithCtx(t *testing.T) {
 	c.Nil(err)
 	c.NotEmpty(relay)
 }
+
+func TestRelayer_ChainRegistry(t *testing.T) {
+	c := require.New(t)
+
+	clientSigner, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	providerMock := &providerMock{relays: map[string]int{}}
+
+	registry, err := relaychain.NewRegistry(relaychain.Chain{ID: "0001", Protocol: relaychain.REST, Path: "/v1/query/height"})
+	c.NoError(err)
+
+	relayer := NewRelayer(clientSigner, providerMock)
+	relayer.SetChainRegistry(registry)
+
+	input := &Input{
+		Blockchain: "0001",
+		Method:     http.MethodPost,
+		PocketAAT:  &provider.PocketAAT{AppPubKey: "app"},
+		Session: &provider.Session{
+			Header: provider.SessionHeader{AppPublicKey: "app", Chain: "0001", SessionHeight: 21},
+			Nodes:  []provider.Node{{PublicKey: "node1"}},
+		},
+	}
+
+	_, err = relayer.Relay(input, nil)
+	c.NoError(err)
+	c.Equal("/v1/query/height", providerMock.inputs[0].Payload.Path)
+
+	input.Path = "/v1/query/block"
+
+	_, err = relayer.Relay(input, nil)
+	c.NoError(err)
+	c.Equal("/v1/query/block", providerMock.inputs[1].Payload.Path)
+
+	input.Path = ""
+	input.Blockchain = "0021"
+
+	_, err = relayer.Relay(input, nil)
+	c.NoError(err)
+	c.Empty(providerMock.inputs[2].Payload.Path)
+}
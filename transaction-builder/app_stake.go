@@ -0,0 +1,205 @@
+package transactionbuilder
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+const (
+	baseRelaysPerPOKTParam   = "application/BaseRelaysPerPOKT"
+	stabilityAdjustmentParam = "application/StabilityAdjustment"
+	participationRateOnParam = "application/ParticipationRateOn"
+	appStakeMinimumParam     = "application/ApplicationStakeMinimum"
+
+	// BaseRelaysPerPOKT is a percentage and stakes are in upokt
+	relaysPerStakeDivisor = 100 * 1000000
+)
+
+var (
+	// ErrNonPositiveRelays error when the relays are zero or negative
+	ErrNonPositiveRelays = errors.New("relays must be greater than zero")
+	// ErrNoParticipationRate error when the network weights relays by participation rate and none is set
+	ErrNoParticipationRate = errors.New("participation rate is on, set it with SetParticipationRate")
+	// ErrNoRelaysPerStake error when the params give no relays for any stake
+	ErrNoRelaysPerStake = errors.New("base relays per POKT is zero")
+	// ErrStakeOverflow error when the stake needed for the relays does not fit in an int64
+	ErrStakeOverflow = errors.New("required stake overflows int64")
+)
+
+// AppStakeProvider interface representing provider functions necessary for AppStakeCalculator
+type AppStakeProvider interface {
+	GetAllParamsWithCtx(ctx context.Context, options *provider.GetAllParamsOptions) (*provider.AllParams, error)
+}
+
+// AppStakeParams represents the app params the max relays of an app depend on
+type AppStakeParams struct {
+	// BaseRelaysPerPOKT is the BaselineThroughputStakeRate, the relays per session of 100 POKT staked
+	BaseRelaysPerPOKT   int64
+	StabilityAdjustment int64
+	ParticipationRateOn bool
+	// StakeMinimum is the minimum app stake in upokt
+	StakeMinimum int64
+}
+
+// AppStakeCalculator relates the stake of an app with its max relays like pocket-core does
+// Max relays are the relays of the app per session, split evenly across its chains and the session nodes
+type AppStakeCalculator struct {
+	provider          AppStakeProvider
+	participationRate *big.Rat
+}
+
+// NewAppStakeCalculator returns an instance of AppStakeCalculator
+func NewAppStakeCalculator(provider AppStakeProvider) *AppStakeCalculator {
+	return &AppStakeCalculator{provider: provider}
+}
+
+// SetParticipationRate sets the staked tokens of apps and nodes over the total supply,
+// only used when the network has the participation rate on
+func (a *AppStakeCalculator) SetParticipationRate(rate float64) {
+	a.participationRate = new(big.Rat).SetFloat64(rate)
+}
+
+// Params returns the current app params the max relays depend on
+func (a *AppStakeCalculator) Params() (*AppStakeParams, error) {
+	return a.ParamsWithCtx(context.Background())
+}
+
+// ParamsWithCtx returns the current app params the max relays depend on
+func (a *AppStakeCalculator) ParamsWithCtx(ctx context.Context) (*AppStakeParams, error) {
+	if a.provider == nil {
+		return nil, ErrNoProvider
+	}
+
+	params, err := a.provider.GetAllParamsWithCtx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	baseRelays, err := getIntParam(params, baseRelaysPerPOKTParam)
+	if err != nil {
+		return nil, err
+	}
+
+	stabilityAdjustment, err := getIntParam(params, stabilityAdjustmentParam)
+	if err != nil {
+		return nil, err
+	}
+
+	stakeMinimum, err := getIntParam(params, appStakeMinimumParam)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AppStakeParams{
+		BaseRelaysPerPOKT:   baseRelays,
+		StabilityAdjustment: stabilityAdjustment,
+		ParticipationRateOn: getParam(params, participationRateOnParam) == "true",
+		StakeMinimum:        stakeMinimum,
+	}, nil
+}
+
+// MaxRelays returns the relays per session of an app staking the upokt
+func (a *AppStakeCalculator) MaxRelays(stake int64) (int64, error) {
+	return a.MaxRelaysWithCtx(context.Background(), stake)
+}
+
+// MaxRelaysWithCtx returns the relays per session of an app staking the upokt
+func (a *AppStakeCalculator) MaxRelaysWithCtx(ctx context.Context, stake int64) (int64, error) {
+	if stake <= 0 {
+		return 0, ErrNonPositiveAmount
+	}
+
+	params, rate, err := a.paramsAndRate(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// relays = rate * base / 100 * stake / 1000000 + adjustment, truncated
+	relays := new(big.Rat).Mul(rate, big.NewRat(params.BaseRelaysPerPOKT, relaysPerStakeDivisor))
+	relays.Mul(relays, new(big.Rat).SetInt64(stake))
+	relays.Add(relays, new(big.Rat).SetInt64(params.StabilityAdjustment))
+
+	maxRelays := new(big.Int).Quo(relays.Num(), relays.Denom())
+	if !maxRelays.IsInt64() {
+		return math.MaxInt64, nil
+	}
+
+	return maxRelays.Int64(), nil
+}
+
+// RequiredStake returns the least upokt an app must stake to get the relays per session,
+// never lower than the app stake minimum
+func (a *AppStakeCalculator) RequiredStake(relays int64) (int64, error) {
+	return a.RequiredStakeWithCtx(context.Background(), relays)
+}
+
+// RequiredStakeWithCtx returns the least upokt an app must stake to get the relays per session,
+// never lower than the app stake minimum
+func (a *AppStakeCalculator) RequiredStakeWithCtx(ctx context.Context, relays int64) (int64, error) {
+	if relays <= 0 {
+		return 0, ErrNonPositiveRelays
+	}
+
+	params, rate, err := a.paramsAndRate(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	relaysPerStake := new(big.Rat).Mul(rate, big.NewRat(params.BaseRelaysPerPOKT, relaysPerStakeDivisor))
+	if relaysPerStake.Sign() <= 0 {
+		return 0, ErrNoRelaysPerStake
+	}
+
+	// Max relays are truncated, so the stake must reach the relays without the adjustment exactly
+	stake := new(big.Rat).SetInt(new(big.Int).Sub(big.NewInt(relays), big.NewInt(params.StabilityAdjustment)))
+	stake.Quo(stake, relaysPerStake)
+
+	required, remainder := new(big.Int).QuoRem(stake.Num(), stake.Denom(), new(big.Int))
+	if remainder.Sign() > 0 {
+		required.Add(required, big.NewInt(1))
+	}
+
+	// The adjustment alone can reach the relays
+	if required.Sign() < 0 || required.Cmp(big.NewInt(params.StakeMinimum)) < 0 {
+		return params.StakeMinimum, nil
+	}
+
+	if !required.IsInt64() {
+		return 0, ErrStakeOverflow
+	}
+
+	return required.Int64(), nil
+}
+
+// paramsAndRate returns the app params and the participation rate they apply, one when it is off
+func (a *AppStakeCalculator) paramsAndRate(ctx context.Context) (*AppStakeParams, *big.Rat, error) {
+	params, err := a.ParamsWithCtx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !params.ParticipationRateOn {
+		return params, big.NewRat(1, 1), nil
+	}
+
+	if a.participationRate == nil {
+		return nil, nil, ErrNoParticipationRate
+	}
+
+	return params, a.participationRate, nil
+}
+
+// getIntParam returns the value of the app or node param, zero when it is not set
+func getIntParam(params *provider.AllParams, key string) (int64, error) {
+	value := getParam(params, key)
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,112 @@
+package transactionbuilder
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppStakeCalculator(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+
+	calculator := NewAppStakeCalculator(rpcProvider)
+
+	params, err := calculator.Params()
+	c.NoError(err)
+	c.Equal(&AppStakeParams{BaseRelaysPerPOKT: 200000, StakeMinimum: 1000000}, params)
+
+	maxRelays, err := calculator.MaxRelays(10000000)
+	c.NoError(err)
+	c.Equal(int64(20000), maxRelays)
+
+	stake, err := calculator.RequiredStake(20000)
+	c.NoError(err)
+	c.Equal(int64(10000000), stake)
+
+	// Relays are truncated, so one more relay needs the stake of a whole one
+	stake, err = calculator.RequiredStake(20001)
+	c.NoError(err)
+	c.Equal(int64(10000500), stake)
+
+	maxRelays, err = calculator.MaxRelays(stake - 1)
+	c.NoError(err)
+	c.Equal(int64(20000), maxRelays)
+
+	stake, err = calculator.RequiredStake(1)
+	c.NoError(err)
+	c.Equal(int64(1000000), stake)
+
+	// The stake gets the relays once staked
+	app, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(app.GetAddress(), 100000000)
+
+	stake, err = calculator.RequiredStake(123457)
+	c.NoError(err)
+
+	txMsg, err := NewStakeApp(app.GetPublicKey(), []string{"0001"}, stake)
+	c.NoError(err)
+
+	_, err = NewTransactionBuilder(rpcProvider, app).Submit(Localnet, txMsg, nil)
+	c.NoError(err)
+
+	server.Commit()
+
+	appOutput, err := rpcProvider.GetApp(app.GetAddress(), nil)
+	c.NoError(err)
+	c.Equal(strconv.FormatInt(stake, 10), appOutput.App.StakedTokens)
+	c.Equal("123457", appOutput.App.MaxRelays)
+
+	server.SetParam(stabilityAdjustmentParam, "100")
+
+	stake, err = calculator.RequiredStake(20000)
+	c.NoError(err)
+	c.Equal(int64(9950000), stake)
+
+	maxRelays, err = calculator.MaxRelays(stake)
+	c.NoError(err)
+	c.Equal(int64(20000), maxRelays)
+
+	server.SetParam(participationRateOnParam, "true")
+
+	_, err = calculator.MaxRelays(10000000)
+	c.Equal(ErrNoParticipationRate, err)
+
+	calculator.SetParticipationRate(0.5)
+
+	maxRelays, err = calculator.MaxRelays(10000000)
+	c.NoError(err)
+	c.Equal(int64(10100), maxRelays)
+
+	stake, err = calculator.RequiredStake(10100)
+	c.NoError(err)
+	c.Equal(int64(10000000), stake)
+
+	_, err = calculator.MaxRelays(0)
+	c.Equal(ErrNonPositiveAmount, err)
+
+	_, err = calculator.RequiredStake(0)
+	c.Equal(ErrNonPositiveRelays, err)
+
+	// Stakes past int64 are refused instead of wrapping around
+	server.SetParam(baseRelaysPerPOKTParam, "1")
+
+	_, err = calculator.RequiredStake(math.MaxInt64)
+	c.Equal(ErrStakeOverflow, err)
+
+	server.SetParam(baseRelaysPerPOKTParam, "0")
+
+	_, err = calculator.RequiredStake(10)
+	c.Equal(ErrNoRelaysPerStake, err)
+}
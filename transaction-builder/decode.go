@@ -0,0 +1,166 @@
+package transactionbuilder
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-network/pocket-core/app"
+	coreTypes "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/auth"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+)
+
+var (
+	// ErrNoTransaction error when no transaction is provided
+	ErrNoTransaction = errors.New("no transaction provided")
+	// ErrNoSignerPublicKey error when a transaction is not signed with a public key
+	ErrNoSignerPublicKey = errors.New("transaction has no signer public key")
+	// ErrSignerNotAllowed error when the signer of a transaction is not a signer of its message
+	ErrSignerNotAllowed = errors.New("transaction signer is not a signer of its message")
+)
+
+// DecodedTransaction represents the readable content of a signed transaction
+type DecodedTransaction struct {
+	// Hash is the hash the network gives to the transaction
+	Hash        string `json:"hash"`
+	MessageType string `json:"message_type"`
+	// Message is the typed message, i.e. *nodesTypes.MsgSend for pos/Send
+	Message TransactionMessage `json:"-"`
+	// MessageValue holds the fields of the message as shown in its amino JSON
+	MessageValue    map[string]any `json:"message_value"`
+	Fee             int64          `json:"fee"`
+	CoinDenom       CoinDenom      `json:"coin_denom"`
+	Memo            string         `json:"memo"`
+	Entropy         int64          `json:"entropy"`
+	SignerPublicKey string         `json:"signer_public_key"`
+	SignerAddress   string         `json:"signer_address"`
+	// Signature is hex encoded
+	Signature string `json:"signature"`
+
+	tx authTypes.StdTx
+}
+
+// DecodeTransaction returns the content of a signed transaction from its raw hex bytes,
+// as in SendTransactionInput.RawHexBytes
+func DecodeTransaction(rawHex string) (*DecodedTransaction, error) {
+	txBytes, err := hex.DecodeString(rawHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeTransactionBytes(txBytes)
+}
+
+// DecodeQueriedTransaction returns the content of a transaction returned by the provider queries,
+// decoding its base64 Tx field
+func DecodeQueriedTransaction(transaction *provider.Transaction) (*DecodedTransaction, error) {
+	if transaction == nil {
+		return nil, ErrNoTransaction
+	}
+
+	txBytes, err := base64.StdEncoding.DecodeString(transaction.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeTransactionBytes(txBytes)
+}
+
+func decodeTransactionBytes(txBytes []byte) (*DecodedTransaction, error) {
+	decodedTX, sdkErr := auth.DefaultTxDecoder(app.Codec())(txBytes, -1)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	tx, ok := decodedTX.(authTypes.StdTx)
+	if !ok {
+		return nil, ErrUnknownMessage
+	}
+
+	txMsg, ok := tx.Msg.(TransactionMessage)
+	if !ok {
+		return nil, ErrUnknownMessage
+	}
+
+	msgJSON, err := app.Codec().MarshalJSON(tx.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var aminoMsg struct {
+		Type  string         `json:"type"`
+		Value map[string]any `json:"value"`
+	}
+
+	if err := json.Unmarshal(msgJSON, &aminoMsg); err != nil {
+		return nil, err
+	}
+
+	decoded := &DecodedTransaction{
+		Hash:         transactionHash(txBytes),
+		MessageType:  aminoMsg.Type,
+		Message:      txMsg,
+		MessageValue: aminoMsg.Value,
+		Memo:         tx.Memo,
+		Entropy:      tx.Entropy,
+		Signature:    hex.EncodeToString(tx.Signature.Signature),
+		tx:           tx,
+	}
+
+	if len(tx.Fee) > 0 {
+		decoded.Fee = tx.Fee[0].Amount.Int64()
+		decoded.CoinDenom = CoinDenom(tx.Fee[0].Denom)
+	}
+
+	if tx.Signature.PublicKey != nil {
+		decoded.SignerPublicKey = tx.Signature.PublicKey.RawString()
+		decoded.SignerAddress = hex.EncodeToString(tx.Signature.PublicKey.Address())
+	}
+
+	return decoded, nil
+}
+
+// VerifySignature checks the transaction is signed for the chain by one of the signers of its message
+func (d *DecodedTransaction) VerifySignature(chainID ChainID) error {
+	publicKey := d.tx.Signature.PublicKey
+	if publicKey == nil {
+		return ErrNoSignerPublicKey
+	}
+
+	if !isMessageSigner(d.tx.Msg, publicKey.Address()) {
+		return ErrSignerNotAllowed
+	}
+
+	signBytes, err := auth.StdSignBytes(string(chainID), d.tx.Entropy, d.tx.Fee, d.tx.Msg, d.tx.Memo)
+	if err != nil {
+		return err
+	}
+
+	if !publicKey.VerifyBytes(signBytes, d.tx.Signature.Signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func isMessageSigner(msg coreTypes.Msg, address []byte) bool {
+	for _, signer := range msg.GetSigners() {
+		if signer.Equals(coreTypes.Address(address)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transactionHash returns the hash Pocket nodes index the transaction with
+func transactionHash(txBytes []byte) string {
+	hash := sha256.Sum256(txBytes)
+
+	return strings.ToUpper(hex.EncodeToString(hash[:]))
+}
@@ -0,0 +1,89 @@
+package transactionbuilder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	nodesTypes "github.com/pokt-network/pocket-core/x/nodes/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTransaction(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	receiver, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 1000000)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	txBuilder := NewTransactionBuilder(rpcProvider, sender)
+
+	msgSend, err := NewSend(sender.GetAddress(), receiver.GetAddress(), 21)
+	c.NoError(err)
+
+	input, err := txBuilder.CreateTransaction(Localnet, msgSend, &TransactionOptions{Fee: 20000, Memo: "ohana"})
+	c.NoError(err)
+
+	decoded, err := DecodeTransaction(input.RawHexBytes)
+	c.NoError(err)
+	c.Equal("pos/Send", decoded.MessageType)
+	c.Equal(receiver.GetAddress(), decoded.Message.(*nodesTypes.MsgSend).ToAddress.String())
+	c.Equal(sender.GetAddress(), decoded.MessageValue["from_address"])
+	c.Equal("21", decoded.MessageValue["amount"])
+	c.Equal(int64(20000), decoded.Fee)
+	c.Equal(Upokt, decoded.CoinDenom)
+	c.Equal("ohana", decoded.Memo)
+	c.NotZero(decoded.Entropy)
+	c.Equal(sender.GetPublicKey(), decoded.SignerPublicKey)
+	c.Equal(sender.GetAddress(), decoded.SignerAddress)
+
+	c.NoError(decoded.VerifySignature(Localnet))
+	c.Equal(ErrInvalidSignature, decoded.VerifySignature(Mainnet))
+
+	_, err = json.Marshal(decoded)
+	c.NoError(err)
+
+	// The hash is the one given by the network, and queried transactions decode the same
+	output, err := rpcProvider.SendTransaction(input)
+	c.NoError(err)
+	c.Equal(output.Txhash, decoded.Hash)
+
+	server.Commit()
+
+	tx, err := rpcProvider.GetTransaction(output.Txhash, nil)
+	c.NoError(err)
+
+	queried, err := DecodeQueriedTransaction(tx.Transaction)
+	c.NoError(err)
+	c.Equal(decoded, queried)
+
+	_, err = DecodeQueriedTransaction(nil)
+	c.Equal(ErrNoTransaction, err)
+
+	_, err = DecodeTransaction("not-hex")
+	c.Error(err)
+
+	_, err = DecodeTransaction("00")
+	c.Error(err)
+
+	// A transaction signed by another account than the one of the message is not valid
+	msgSend, err = NewSend(receiver.GetAddress(), sender.GetAddress(), 21)
+	c.NoError(err)
+
+	input, err = txBuilder.CreateTransaction(Localnet, msgSend, nil)
+	c.NoError(err)
+
+	decoded, err = DecodeTransaction(input.RawHexBytes)
+	c.NoError(err)
+	c.Equal(ErrSignerNotAllowed, decoded.VerifySignature(Localnet))
+}
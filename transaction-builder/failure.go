@@ -0,0 +1,169 @@
+package transactionbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+// txInCacheMessage is the error of the node for transactions still in its mempool cache
+const txInCacheMessage = "tx already exists in cache"
+
+var (
+	// ErrInsufficientFunds error when the signer can't pay the amount, the stake or the fee of a transaction
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrInsufficientFee error when the fee of a transaction is lower than the one required by the network
+	ErrInsufficientFee = errors.New("insufficient fee")
+	// ErrDuplicateTransaction error when a transaction with the same hash was already sent
+	ErrDuplicateTransaction = errors.New("duplicate transaction")
+	// ErrBelowMinimumStake error when a stake is lower than the stake minimum or the current stake
+	ErrBelowMinimumStake = errors.New("stake below minimum")
+	// ErrTooManyChains error when a stake has more chains than allowed
+	ErrTooManyChains = errors.New("too many chains staked")
+	// ErrInvalidStatus error when an app or node does not exist or is not in the status the message requires
+	ErrInvalidStatus = errors.New("invalid app or node status")
+	// ErrUnauthorizedSigner error when the signer is not allowed to sign the message
+	ErrUnauthorizedSigner = errors.New("unauthorized signer")
+	// ErrInvalidTransaction error when the network rejects the content of a transaction, i.e. a memo too large
+	ErrInvalidTransaction = errors.New("invalid transaction")
+	// ErrNodeInternal error when the node fails to process a transaction for reasons unrelated to it
+	ErrNodeInternal = errors.New("node internal error")
+	// ErrUnknownFailure error when the codespace and code of a failure are not known
+	ErrUnknownFailure = errors.New("unknown transaction failure")
+)
+
+// failureKey represents the codespace and code pocket-core reports failures with
+type failureKey struct {
+	codespace string
+	code      int
+}
+
+var failures = map[failureKey]error{
+	{"sdk", 1}:  ErrNodeInternal,
+	{"sdk", 2}:  ErrInvalidTransaction,
+	{"sdk", 4}:  ErrInvalidSignature,
+	{"sdk", 5}:  ErrInsufficientFunds,
+	{"sdk", 6}:  ErrInvalidTransaction,
+	{"sdk", 7}:  ErrInvalidTransaction,
+	{"sdk", 8}:  ErrInvalidSignature,
+	{"sdk", 10}: ErrInsufficientFunds,
+	{"sdk", 11}: ErrInvalidTransaction,
+	{"sdk", 13}: ErrInvalidTransaction,
+	{"sdk", 14}: ErrInsufficientFee,
+	{"sdk", 15}: ErrInvalidSignature,
+	{"sdk", 17}: ErrInvalidSignature,
+	{"sdk", 18}: ErrInvalidTransaction,
+
+	{"auth", 1}: ErrInvalidTransaction,
+	{"auth", 2}: ErrInvalidSignature,
+	{"auth", 3}: ErrInsufficientFunds,
+	{"auth", 4}: ErrInsufficientFee,
+	{"auth", 5}: ErrInvalidSignature,
+	{"auth", 6}: ErrDuplicateTransaction,
+	{"auth", 7}: ErrInsufficientFunds,
+	{"auth", 8}: ErrNodeInternal,
+
+	{"pos", 101}: ErrInvalidStatus,
+	{"pos", 103}: ErrInvalidTransaction,
+	{"pos", 104}: ErrInvalidStatus,
+	{"pos", 105}: ErrInvalidStatus,
+	{"pos", 110}: ErrInvalidStatus,
+	{"pos", 111}: ErrBelowMinimumStake,
+	{"pos", 112}: ErrInsufficientFunds,
+	{"pos", 115}: ErrInvalidTransaction,
+	{"pos", 116}: ErrInvalidTransaction,
+	{"pos", 117}: ErrInvalidStatus,
+	{"pos", 118}: ErrInvalidTransaction,
+	{"pos", 119}: ErrInvalidTransaction,
+	{"pos", 120}: ErrTooManyChains,
+	{"pos", 122}: ErrBelowMinimumStake,
+	{"pos", 123}: ErrInvalidTransaction,
+	{"pos", 124}: ErrUnauthorizedSigner,
+	{"pos", 125}: ErrUnauthorizedSigner,
+	{"pos", 126}: ErrUnauthorizedSigner,
+
+	{"application", 101}: ErrInvalidStatus,
+	{"application", 103}: ErrInvalidTransaction,
+	{"application", 104}: ErrInvalidStatus,
+	{"application", 105}: ErrInvalidStatus,
+	{"application", 110}: ErrInvalidStatus,
+	{"application", 111}: ErrBelowMinimumStake,
+	{"application", 112}: ErrInsufficientFunds,
+	{"application", 115}: ErrInvalidTransaction,
+	{"application", 116}: ErrInvalidTransaction,
+	{"application", 117}: ErrInvalidTransaction,
+	{"application", 118}: ErrTooManyChains,
+	{"application", 120}: ErrBelowMinimumStake,
+
+	{"gov", 4}: ErrUnauthorizedSigner,
+	{"gov", 5}: ErrUnauthorizedSigner,
+}
+
+// DecodeFailure returns the error matching the codespace and code of a failed transaction, nil for code 0
+// Unknown failures return ErrUnknownFailure
+func DecodeFailure(codespace string, code int, log string) error {
+	if code == 0 {
+		return nil
+	}
+
+	// pocket-core reports insufficient balance for the fee with the duplicate transaction code
+	if codespace == "auth" && code == 6 && strings.Contains(log, "does not have enough coins") {
+		return ErrInsufficientFunds
+	}
+
+	failure, ok := failures[failureKey{codespace: codespace, code: code}]
+	if !ok {
+		return ErrUnknownFailure
+	}
+
+	return failure
+}
+
+// IsUserError returns whether the error is a failure caused by the transaction itself,
+// as opposed to failures of the node or the network
+func IsUserError(err error) bool {
+	var cause error
+
+	var rejectedErr *TransactionRejectedError
+	var failedErr *TransactionFailedError
+
+	switch {
+	case errors.As(err, &rejectedErr):
+		cause = rejectedErr.Unwrap()
+	case errors.As(err, &failedErr):
+		cause = failedErr.Unwrap()
+	default:
+		return false
+	}
+
+	return cause != ErrNodeInternal && cause != ErrUnknownFailure
+}
+
+// TransactionRejectedError error when the node rejects a transaction before including it in a block
+// Unwraps to the error matching its codespace and code, i.e. ErrInsufficientFunds
+type TransactionRejectedError struct {
+	Hash      string
+	Code      int
+	Codespace string
+	Log       string
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *TransactionRejectedError) Error() string {
+	return fmt.Sprintf("transaction %s rejected with code %d in codespace %s: %s", e.Hash, e.Code, e.Codespace, e.Log)
+}
+
+// Unwrap returns the error matching the codespace and code
+func (e *TransactionRejectedError) Unwrap() error {
+	return DecodeFailure(e.Codespace, e.Code, e.Log)
+}
+
+// isTransactionInCache returns whether the node refused the transaction because it is already in its mempool
+func isTransactionInCache(err error) bool {
+	var rpcErr *provider.RPCError
+
+	return errors.As(err, &rpcErr) && strings.Contains(rpcErr.Message, txInCacheMessage)
+}
@@ -0,0 +1,83 @@
+package transactionbuilder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeFailure(t *testing.T) {
+	c := require.New(t)
+
+	tests := []struct {
+		codespace string
+		code      int
+		log       string
+		expected  error
+	}{
+		{codespace: "sdk", code: 0, expected: nil},
+		{codespace: "sdk", code: 10, expected: ErrInsufficientFunds},
+		{codespace: "sdk", code: 4, expected: ErrInvalidSignature},
+		{codespace: "auth", code: 6, log: "this is a duplicate transaction", expected: ErrDuplicateTransaction},
+		{codespace: "auth", code: 6, log: "does not have enough coins for the tx", expected: ErrInsufficientFunds},
+		{codespace: "pos", code: 111, expected: ErrBelowMinimumStake},
+		{codespace: "application", code: 120, expected: ErrBelowMinimumStake},
+		{codespace: "application", code: 118, expected: ErrTooManyChains},
+		{codespace: "pos", code: 125, expected: ErrUnauthorizedSigner},
+		{codespace: "gov", code: 5, expected: ErrUnauthorizedSigner},
+		{codespace: "sdk", code: 1, expected: ErrNodeInternal},
+		{codespace: "pocketcore", code: 1, expected: ErrUnknownFailure},
+	}
+
+	for _, tt := range tests {
+		c.Equal(tt.expected, DecodeFailure(tt.codespace, tt.code, tt.log), "%s %d", tt.codespace, tt.code)
+	}
+
+	c.True(IsUserError(&TransactionRejectedError{Codespace: "auth", Code: 6}))
+	c.True(IsUserError(&TransactionFailedError{Codespace: "pos", Code: 111}))
+	c.False(IsUserError(&TransactionRejectedError{Codespace: "sdk", Code: 1}))
+	c.False(IsUserError(&TransactionFailedError{Codespace: "pocketcore", Code: 1}))
+	c.False(IsUserError(errors.New("connection refused")))
+}
+
+func TestTransactionBuilder_SubmitRejected(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 100000)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	txBuilder := NewTransactionBuilder(rpcProvider, sender)
+
+	msgSend, err := NewSend(sender.GetAddress(), sender.GetAddress(), 21)
+	c.NoError(err)
+
+	unsignedTX, err := txBuilder.CreateUnsignedTransaction(Localnet, msgSend, nil)
+	c.NoError(err)
+
+	signature, err := SignTransaction(sender, unsignedTX)
+	c.NoError(err)
+
+	output, err := txBuilder.SubmitSigned(unsignedTX, signature)
+	c.NoError(err)
+	c.Zero(output.Code)
+
+	_, err = txBuilder.SubmitSigned(unsignedTX, signature)
+	c.ErrorIs(err, ErrDuplicateTransaction)
+
+	var rejectedErr *TransactionRejectedError
+	c.ErrorAs(err, &rejectedErr)
+	c.Equal(output.Txhash, rejectedErr.Hash)
+	c.Equal("auth", rejectedErr.Codespace)
+	c.Equal(6, rejectedErr.Code)
+	c.True(IsUserError(err))
+}
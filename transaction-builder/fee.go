@@ -0,0 +1,129 @@
+package transactionbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+)
+
+const feeMultipliersParam = "auth/FeeMultipliers"
+
+// InsufficientFeeError error when the fee of a transaction is lower than the one required by the network
+type InsufficientFeeError struct {
+	MessageType string
+	Required    int64
+	Provided    int64
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *InsufficientFeeError) Error() string {
+	return fmt.Sprintf("insufficient fee for %s: required %d upokt, provided %d upokt", e.MessageType, e.Required, e.Provided)
+}
+
+// ParamsProvider interface representing provider functions necessary for FeeSchedule
+type ParamsProvider interface {
+	GetBlockHeightWithCtx(ctx context.Context) (int, error)
+	GetAllParamsWithCtx(ctx context.Context, options *provider.GetAllParamsOptions) (*provider.AllParams, error)
+}
+
+// FeeSchedule computes the fees required by the network with the auth/FeeMultipliers param
+// Multipliers are keyed by the message type, i.e. "send" or "stake_app"
+// The param is cached until the height changes
+type FeeSchedule struct {
+	provider    ParamsProvider
+	mu          sync.Mutex
+	height      int
+	multipliers *authTypes.FeeMultipliers
+}
+
+// NewFeeSchedule returns an instance of FeeSchedule
+func NewFeeSchedule(provider ParamsProvider) *FeeSchedule {
+	return &FeeSchedule{provider: provider}
+}
+
+// RequiredFee returns the fee in upokt the network requires for the message
+func (f *FeeSchedule) RequiredFee(txMsg TransactionMessage) (int64, error) {
+	return f.RequiredFeeWithCtx(context.Background(), txMsg)
+}
+
+// RequiredFeeWithCtx returns the fee in upokt the network requires for the message
+func (f *FeeSchedule) RequiredFeeWithCtx(ctx context.Context, txMsg TransactionMessage) (int64, error) {
+	if txMsg == nil {
+		return 0, ErrNoTransactionMessage
+	}
+
+	multipliers, err := f.feeMultipliers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return multipliers.GetFee(txMsg).Int64(), nil
+}
+
+func (f *FeeSchedule) feeMultipliers(ctx context.Context) (*authTypes.FeeMultipliers, error) {
+	height, err := f.provider.GetBlockHeightWithCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.multipliers != nil && f.height == height {
+		return f.multipliers, nil
+	}
+
+	params, err := f.provider.GetAllParamsWithCtx(ctx, &provider.GetAllParamsOptions{Height: height})
+	if err != nil {
+		return nil, err
+	}
+
+	multipliers, err := parseFeeMultipliers(params)
+	if err != nil {
+		return nil, err
+	}
+
+	f.height = height
+	f.multipliers = multipliers
+
+	return multipliers, nil
+}
+
+// parseFeeMultipliers returns the auth/FeeMultipliers param, the network default when it is not set
+// Amino encodes its integers as strings
+func parseFeeMultipliers(params *provider.AllParams) (*authTypes.FeeMultipliers, error) {
+	multipliers := authTypes.DefaultFeeMultiplier
+
+	value, ok := params.AuthParams.Get(feeMultipliersParam)
+	if !ok {
+		return &multipliers, nil
+	}
+
+	var raw struct {
+		FeeMultis []struct {
+			Key        string `json:"key"`
+			Multiplier int64  `json:"multiplier,string"`
+		} `json:"fee_multiplier"`
+		Default int64 `json:"default,string"`
+	}
+
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		return nil, fmt.Errorf("invalid %s param: %w", feeMultipliersParam, err)
+	}
+
+	multipliers = authTypes.FeeMultipliers{Default: raw.Default}
+
+	for _, multiplier := range raw.FeeMultis {
+		multipliers.FeeMultis = append(multipliers.FeeMultis, authTypes.FeeMultiplier{
+			Key:        multiplier.Key,
+			Multiplier: multiplier.Multiplier,
+		})
+	}
+
+	return &multipliers, nil
+}
@@ -0,0 +1,104 @@
+package transactionbuilder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+type paramsProviderMock struct {
+	*provider.Provider
+	paramsCalls int
+}
+
+func (p *paramsProviderMock) GetAllParamsWithCtx(ctx context.Context, options *provider.GetAllParamsOptions) (*provider.AllParams, error) {
+	p.paramsCalls++
+
+	return p.Provider.GetAllParamsWithCtx(ctx, options)
+}
+
+func TestTransactionBuilder_FeeSchedule(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 1000000)
+	server.SetParam("auth/FeeMultipliers", `{"fee_multiplier":[{"key":"send","multiplier":"3"}],"default":"2"}`)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	paramsProvider := &paramsProviderMock{Provider: rpcProvider}
+	feeSchedule := NewFeeSchedule(paramsProvider)
+
+	txBuilder := NewTransactionBuilder(rpcProvider, sender)
+	txBuilder.SetFeeCalculator(feeSchedule)
+
+	msgSend, err := NewSend(sender.GetAddress(), sender.GetAddress(), 21)
+	c.NoError(err)
+
+	unstakeApp, err := NewUnstakeApp(sender.GetAddress())
+	c.NoError(err)
+
+	fee, err := feeSchedule.RequiredFee(msgSend)
+	c.NoError(err)
+	c.Equal(int64(30000), fee)
+
+	fee, err = feeSchedule.RequiredFee(unstakeApp)
+	c.NoError(err)
+	c.Equal(int64(20000), fee)
+
+	// Params are fetched once per height
+	c.Equal(1, paramsProvider.paramsCalls)
+
+	// Transactions without fee pay the required one
+	unsignedTX, err := txBuilder.CreateUnsignedTransaction(Localnet, msgSend, nil)
+	c.NoError(err)
+	c.Equal(int64(30000), unsignedTX.Fee)
+
+	output, err := txBuilder.Submit(Localnet, msgSend, nil)
+	c.NoError(err)
+
+	server.Commit()
+
+	tx, err := rpcProvider.GetTransaction(output.Txhash, nil)
+	c.NoError(err)
+	c.Zero(tx.TxResult.Code)
+
+	// Fees lower than required are rejected before sending
+	_, err = txBuilder.Submit(Localnet, msgSend, &TransactionOptions{Fee: 10000})
+
+	var feeErr *InsufficientFeeError
+	c.ErrorAs(err, &feeErr)
+	c.Equal("send", feeErr.MessageType)
+	c.Equal(int64(30000), feeErr.Required)
+	c.Equal(int64(10000), feeErr.Provided)
+
+	unsignedTX, err = txBuilder.CreateUnsignedTransaction(Localnet, msgSend, &TransactionOptions{Fee: 40000})
+	c.NoError(err)
+	c.Equal(int64(40000), unsignedTX.Fee)
+
+	// Governance changes are seen on the next height
+	server.SetParam("auth/FeeMultipliers", `{"fee_multiplier":null,"default":"1"}`)
+	server.Commit()
+
+	fee, err = feeSchedule.RequiredFee(msgSend)
+	c.NoError(err)
+	c.Equal(int64(10000), fee)
+	c.Equal(3, paramsProvider.paramsCalls)
+
+	server.SetParam("auth/FeeMultipliers", "not json")
+	server.Commit()
+
+	_, err = feeSchedule.RequiredFee(msgSend)
+	c.ErrorContains(err, "invalid auth/FeeMultipliers param")
+
+	_, err = feeSchedule.RequiredFee(nil)
+	c.Equal(ErrNoTransactionMessage, err)
+}
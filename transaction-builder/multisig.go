@@ -0,0 +1,169 @@
+package transactionbuilder
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pokt-network/pocket-core/crypto"
+)
+
+var (
+	// ErrNotEnoughMultisigKeys error when a multisig account has less than two public keys
+	ErrNotEnoughMultisigKeys = errors.New("multisig account needs at least two public keys")
+	// ErrDuplicateMultisigKey error when a public key is repeated in a multisig account
+	ErrDuplicateMultisigKey = errors.New("duplicate public key in multisig account")
+	// ErrUnsupportedThreshold error when the threshold is not the amount of public keys
+	// Pocket multisig accounts need the signature of every key
+	ErrUnsupportedThreshold = errors.New("multisig threshold must be the amount of public keys")
+	// ErrUnknownMultisigSigner error when a signature comes from a key not in the multisig account
+	ErrUnknownMultisigSigner = errors.New("signer is not a key of the multisig account")
+)
+
+// MissingSignaturesError error when a multisig transaction lacks signatures of some of its keys
+type MissingSignaturesError struct {
+	PublicKeys []string
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *MissingSignaturesError) Error() string {
+	return fmt.Sprintf("missing signatures of public keys: %s", strings.Join(e.PublicKeys, ", "))
+}
+
+// MultisigAccount represents an account controlled by several public keys
+// Its transactions need a partial signature of each key over the same sign bytes
+type MultisigAccount struct {
+	publicKey crypto.PublicKeyMultiSig
+}
+
+// NewMultisigAccount returns an instance of MultisigAccount from the public keys in the given order
+// The order changes the address, so every party has to use the same one
+func NewMultisigAccount(publicKeys []string, threshold int) (*MultisigAccount, error) {
+	if len(publicKeys) < 2 {
+		return nil, ErrNotEnoughMultisigKeys
+	}
+
+	if threshold != len(publicKeys) {
+		return nil, ErrUnsupportedThreshold
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(publicKeys))
+
+	for _, publicKey := range publicKeys {
+		key, err := crypto.NewPublicKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if multisigKeyIndex(keys, key) != -1 {
+			return nil, ErrDuplicateMultisigKey
+		}
+
+		keys = append(keys, key)
+	}
+
+	multisigKey, err := crypto.PublicKeyMultiSignature{}.NewMultiKey(keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultisigAccount{publicKey: multisigKey}, nil
+}
+
+// GetAddress returns the address of the multisig account
+func (m *MultisigAccount) GetAddress() string {
+	return hex.EncodeToString(m.publicKey.Address())
+}
+
+// GetPublicKey returns the multisig public key
+func (m *MultisigAccount) GetPublicKey() string {
+	return m.publicKey.RawString()
+}
+
+// PublicKeys returns the public keys of the multisig account in order
+func (m *MultisigAccount) PublicKeys() []string {
+	publicKeys := make([]string, 0, len(m.publicKey.Keys()))
+
+	for _, key := range m.publicKey.Keys() {
+		publicKeys = append(publicKeys, key.RawString())
+	}
+
+	return publicKeys
+}
+
+// CombineSignatures returns the signature of the multisig account from the partial signatures of its keys,
+// returned by SignTransaction, to be used with CombineTransaction
+func (m *MultisigAccount) CombineSignatures(unsignedTX *UnsignedTransaction, signatures []*TransactionSignature) (*TransactionSignature, error) {
+	if _, err := unsignedTX.verify(); err != nil {
+		return nil, err
+	}
+
+	keys := m.publicKey.Keys()
+	orderedSignatures := make([][]byte, len(keys))
+
+	for _, signature := range signatures {
+		index, decodedSignature, err := m.verifyPartialSignature(unsignedTX, signature)
+		if err != nil {
+			return nil, err
+		}
+
+		orderedSignatures[index] = decodedSignature
+	}
+
+	var missingKeys []string
+
+	for i, signature := range orderedSignatures {
+		if signature == nil {
+			missingKeys = append(missingKeys, keys[i].RawString())
+		}
+	}
+
+	if len(missingKeys) > 0 {
+		return nil, &MissingSignaturesError{PublicKeys: missingKeys}
+	}
+
+	return &TransactionSignature{
+		PublicKey: m.GetPublicKey(),
+		Signature: hex.EncodeToString(crypto.MultiSignature{Sigs: orderedSignatures}.Marshal()),
+	}, nil
+}
+
+// verifyPartialSignature returns the index of the key of the signature and the decoded signature
+func (m *MultisigAccount) verifyPartialSignature(unsignedTX *UnsignedTransaction, signature *TransactionSignature) (int, []byte, error) {
+	if signature == nil {
+		return 0, nil, ErrNoSignature
+	}
+
+	key, err := crypto.NewPublicKey(signature.PublicKey)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	index := multisigKeyIndex(m.publicKey.Keys(), key)
+	if index == -1 {
+		return 0, nil, ErrUnknownMultisigSigner
+	}
+
+	decodedSignature, err := hex.DecodeString(signature.Signature)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !key.VerifyBytes([]byte(unsignedTX.SignBytes), decodedSignature) {
+		return 0, nil, ErrInvalidSignature
+	}
+
+	return index, decodedSignature, nil
+}
+
+func multisigKeyIndex(keys []crypto.PublicKey, key crypto.PublicKey) int {
+	for i, multisigKey := range keys {
+		if multisigKey.Equals(key) {
+			return i
+		}
+	}
+
+	return -1
+}
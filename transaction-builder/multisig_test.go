@@ -0,0 +1,142 @@
+package transactionbuilder
+
+import (
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultisigAccount(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	var (
+		signers    []*signer.Signer
+		publicKeys []string
+	)
+
+	for i := 0; i < 3; i++ {
+		keySigner, err := signer.NewRandomSigner()
+		c.NoError(err)
+
+		signers = append(signers, keySigner)
+		publicKeys = append(publicKeys, keySigner.GetPublicKey())
+	}
+
+	account, err := NewMultisigAccount(publicKeys, 3)
+	c.NoError(err)
+	c.Len(account.GetAddress(), 40)
+	c.Equal(publicKeys, account.PublicKeys())
+
+	// The same keys in the same order give the same account
+	sameAccount, err := NewMultisigAccount(publicKeys, 3)
+	c.NoError(err)
+	c.Equal(account.GetAddress(), sameAccount.GetAddress())
+	c.Equal(account.GetPublicKey(), sameAccount.GetPublicKey())
+
+	server.SetBalance(account.GetAddress(), 1000000)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	txBuilder := NewTransactionBuilder(rpcProvider, nil)
+
+	msgSend, err := NewSend(account.GetAddress(), signers[0].GetAddress(), 21)
+	c.NoError(err)
+
+	unsignedTX, err := txBuilder.CreateUnsignedTransaction(Localnet, msgSend, nil)
+	c.NoError(err)
+
+	// Partial signatures can come in any order
+	var signatures []*TransactionSignature
+
+	for i := len(signers) - 1; i >= 0; i-- {
+		signature, err := SignTransaction(signers[i], unsignedTX)
+		c.NoError(err)
+
+		signatures = append(signatures, signature)
+	}
+
+	_, err = account.CombineSignatures(unsignedTX, signatures[:2])
+
+	var missingErr *MissingSignaturesError
+	c.ErrorAs(err, &missingErr)
+	c.Equal([]string{publicKeys[0]}, missingErr.PublicKeys)
+
+	multisigSignature, err := account.CombineSignatures(unsignedTX, signatures)
+	c.NoError(err)
+	c.Equal(account.GetPublicKey(), multisigSignature.PublicKey)
+
+	input, err := CombineTransaction(unsignedTX, multisigSignature)
+	c.NoError(err)
+	c.Equal(account.GetAddress(), input.Address)
+
+	output, err := txBuilder.SubmitSigned(unsignedTX, multisigSignature)
+	c.NoError(err)
+
+	server.Commit()
+
+	tx, err := rpcProvider.GetTransaction(output.Txhash, nil)
+	c.NoError(err)
+	c.Zero(tx.TxResult.Code)
+	c.Equal(account.GetAddress(), tx.TxResult.Signer)
+	c.Equal(int64(21), server.Balance(signers[0].GetAddress()).Int64())
+}
+
+func TestMultisigAccountErrors(t *testing.T) {
+	c := require.New(t)
+
+	first, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	second, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	outsider, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	_, err = NewMultisigAccount([]string{first.GetPublicKey()}, 1)
+	c.Equal(ErrNotEnoughMultisigKeys, err)
+
+	_, err = NewMultisigAccount([]string{first.GetPublicKey(), second.GetPublicKey()}, 1)
+	c.Equal(ErrUnsupportedThreshold, err)
+
+	_, err = NewMultisigAccount([]string{first.GetPublicKey(), first.GetPublicKey()}, 2)
+	c.Equal(ErrDuplicateMultisigKey, err)
+
+	_, err = NewMultisigAccount([]string{first.GetPublicKey(), "not-hex"}, 2)
+	c.Error(err)
+
+	account, err := NewMultisigAccount([]string{first.GetPublicKey(), second.GetPublicKey()}, 2)
+	c.NoError(err)
+
+	msgSend, err := NewSend(account.GetAddress(), outsider.GetAddress(), 21)
+	c.NoError(err)
+
+	unsignedTX, err := NewTransactionBuilder(nil, nil).CreateUnsignedTransaction(Mainnet, msgSend, nil)
+	c.NoError(err)
+
+	outsiderSignature, err := SignTransaction(outsider, unsignedTX)
+	c.NoError(err)
+
+	_, err = account.CombineSignatures(unsignedTX, []*TransactionSignature{outsiderSignature})
+	c.Equal(ErrUnknownMultisigSigner, err)
+
+	firstSignature, err := SignTransaction(first, unsignedTX)
+	c.NoError(err)
+
+	secondSignature, err := SignTransaction(second, unsignedTX)
+	c.NoError(err)
+
+	// A signature made with another key is rejected
+	secondSignature.Signature = firstSignature.Signature
+
+	_, err = account.CombineSignatures(unsignedTX, []*TransactionSignature{firstSignature, secondSignature})
+	c.Equal(ErrInvalidSignature, err)
+
+	_, err = account.CombineSignatures(unsignedTX, []*TransactionSignature{nil})
+	c.Equal(ErrNoSignature, err)
+}
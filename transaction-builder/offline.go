@@ -0,0 +1,240 @@
+package transactionbuilder
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-network/pocket-core/app"
+	"github.com/pokt-network/pocket-core/crypto"
+	coreTypes "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/auth"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+)
+
+var (
+	// ErrNoUnsignedTransaction error when no unsigned transaction is provided
+	ErrNoUnsignedTransaction = errors.New("no unsigned transaction provided")
+	// ErrNoSignature error when no signature is provided
+	ErrNoSignature = errors.New("no signature provided")
+	// ErrSignBytesMismatch error when the sign bytes of an unsigned transaction do not match its fields
+	ErrSignBytesMismatch = errors.New("sign bytes do not match the transaction")
+	// ErrInvalidSignature error when a signature does not verify against the transaction
+	ErrInvalidSignature = errors.New("invalid transaction signature")
+	// ErrUnknownMessage error when the message of an unsigned transaction is not a Pocket message
+	ErrUnknownMessage = errors.New("unknown transaction message")
+)
+
+// UnsignedTransaction represents a transaction built without signing it, it can be serialized to JSON
+// to be signed in another machine with SignTransaction and combined back with CombineTransaction
+type UnsignedTransaction struct {
+	ChainID   ChainID   `json:"chain_id"`
+	Entropy   int64     `json:"entropy"`
+	Fee       int64     `json:"fee"`
+	CoinDenom CoinDenom `json:"coin_denom"`
+	Memo      string    `json:"memo"`
+	// Msg is the amino JSON of the message, i.e. {"type":"pos/Send","value":{...}}
+	Msg json.RawMessage `json:"msg"`
+	// SignBytes is the payload to sign, checked against the other fields before signing and combining
+	SignBytes string `json:"sign_bytes"`
+}
+
+// TransactionSignature represents the signature of an UnsignedTransaction, it can be serialized to JSON
+type TransactionSignature struct {
+	PublicKey string `json:"public_key"`
+	// Signature is hex encoded
+	Signature string `json:"signature"`
+}
+
+// CreateUnsignedTransaction returns the transaction ready to be signed, no signer is needed
+func (t *TransactionBuilder) CreateUnsignedTransaction(chainID ChainID, txMsg TransactionMessage, options *TransactionOptions) (*UnsignedTransaction, error) {
+	return t.CreateUnsignedTransactionWithCtx(context.Background(), chainID, txMsg, options)
+}
+
+// CreateUnsignedTransactionWithCtx returns the transaction ready to be signed, no signer is needed
+func (t *TransactionBuilder) CreateUnsignedTransactionWithCtx(ctx context.Context, chainID ChainID, txMsg TransactionMessage, options *TransactionOptions) (*UnsignedTransaction, error) {
+	err := t.validateTransactionMessage(chainID, txMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	memo, coinDenom, _ := getOptionalParams(options)
+
+	fee, err := t.transactionFee(ctx, txMsg, options)
+	if err != nil {
+		return nil, err
+	}
+
+	entropy, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+
+	msgJSON, err := app.Codec().MarshalJSON(txMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedTX := &UnsignedTransaction{
+		ChainID:   chainID,
+		Entropy:   entropy.Int64(),
+		Fee:       fee,
+		CoinDenom: CoinDenom(coinDenom),
+		Memo:      memo,
+		Msg:       msgJSON,
+	}
+
+	signBytes, err := auth.StdSignBytes(string(chainID), unsignedTX.Entropy, unsignedTX.fees(), txMsg, memo)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedTX.SignBytes = string(signBytes)
+
+	return unsignedTX, nil
+}
+
+// SignTransaction returns the signature of the unsigned transaction, the signer does not need network access
+func SignTransaction(signer Signer, unsignedTX *UnsignedTransaction) (*TransactionSignature, error) {
+	if signer == nil {
+		return nil, ErrNoSigner
+	}
+
+	if _, err := unsignedTX.verify(); err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.SignBytes([]byte(unsignedTX.SignBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionSignature{
+		PublicKey: signer.GetPublicKey(),
+		Signature: hex.EncodeToString(signature),
+	}, nil
+}
+
+// CombineTransaction returns input necessary for doing a transaction from the unsigned transaction and its signature
+func CombineTransaction(unsignedTX *UnsignedTransaction, signature *TransactionSignature) (*provider.SendTransactionInput, error) {
+	if signature == nil {
+		return nil, ErrNoSignature
+	}
+
+	txMsg, err := unsignedTX.verify()
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := crypto.NewPublicKey(signature.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedSignature, err := hex.DecodeString(signature.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if !publicKey.VerifyBytes([]byte(unsignedTX.SignBytes), decodedSignature) {
+		return nil, ErrInvalidSignature
+	}
+
+	signatureStruct := authTypes.StdSignature{PublicKey: publicKey, Signature: decodedSignature}
+
+	tx := authTypes.NewTx(txMsg, unsignedTX.fees(), signatureStruct, unsignedTX.Memo, unsignedTX.Entropy)
+
+	txBytes, err := auth.DefaultTxEncoder(app.Codec())(tx, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider.SendTransactionInput{
+		Address:     hex.EncodeToString(publicKey.Address()),
+		RawHexBytes: hex.EncodeToString(txBytes),
+	}, nil
+}
+
+// SubmitSigned does the transaction from the unsigned transaction and its signature
+func (t *TransactionBuilder) SubmitSigned(unsignedTX *UnsignedTransaction, signature *TransactionSignature) (*provider.SendTransactionOutput, error) {
+	return t.SubmitSignedWithCtx(context.Background(), unsignedTX, signature)
+}
+
+// SubmitSignedWithCtx does the transaction from the unsigned transaction and its signature
+// Returns TransactionRejectedError when the node rejects the transaction
+func (t *TransactionBuilder) SubmitSignedWithCtx(ctx context.Context, unsignedTX *UnsignedTransaction, signature *TransactionSignature) (*provider.SendTransactionOutput, error) {
+	if t.provider == nil {
+		return nil, ErrNoProvider
+	}
+
+	sendTransactionInput, err := CombineTransaction(unsignedTX, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.sendTransaction(ctx, sendTransactionInput)
+}
+
+func (u *UnsignedTransaction) fees() coreTypes.Coins {
+	return coreTypes.Coins{
+		coreTypes.Coin{
+			Amount: coreTypes.NewInt(u.Fee),
+			Denom:  string(u.CoinDenom),
+		},
+	}
+}
+
+// verify returns the decoded message checking the sign bytes match the fields of the transaction,
+// so what is signed is what is reviewed and later sent
+func (u *UnsignedTransaction) verify() (TransactionMessage, error) {
+	if u == nil {
+		return nil, ErrNoUnsignedTransaction
+	}
+
+	txMsg, err := decodeTransactionMessage(u.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	signBytes, err := auth.StdSignBytes(string(u.ChainID), u.Entropy, u.fees(), txMsg, u.Memo)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(signBytes) != u.SignBytes {
+		return nil, ErrSignBytesMismatch
+	}
+
+	return txMsg, nil
+}
+
+// decodeTransactionMessage returns the message from its amino JSON
+// Amino decodes the registered message values, the transaction needs the pointer
+func decodeTransactionMessage(msgJSON json.RawMessage) (TransactionMessage, error) {
+	var msg coreTypes.Msg
+
+	err := app.Codec().UnmarshalJSON(msgJSON, &msg)
+	if err != nil {
+		return nil, err
+	}
+
+	msgValue := reflect.ValueOf(msg)
+	if msgValue.Kind() != reflect.Ptr {
+		pointer := reflect.New(msgValue.Type())
+		pointer.Elem().Set(msgValue)
+		msgValue = pointer
+	}
+
+	txMsg, ok := msgValue.Interface().(TransactionMessage)
+	if !ok {
+		return nil, ErrUnknownMessage
+	}
+
+	return txMsg, nil
+}
@@ -0,0 +1,121 @@
+package transactionbuilder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionBuilder_OfflineSigning(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	coldSigner, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	receiver, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(coldSigner.GetAddress(), 1000000)
+
+	// The online machine builds the transaction without the key
+	txBuilder := NewTransactionBuilder(provider.NewProvider(server.URL, []string{server.URL}), nil)
+
+	msgSend, err := NewSend(coldSigner.GetAddress(), receiver.GetAddress(), 21)
+	c.NoError(err)
+
+	unsignedTX, err := txBuilder.CreateUnsignedTransaction(Localnet, msgSend, &TransactionOptions{Memo: "treasury"})
+	c.NoError(err)
+
+	exported, err := json.MarshalIndent(unsignedTX, "", "  ")
+	c.NoError(err)
+
+	// The air-gapped machine signs the exported transaction
+	var imported UnsignedTransaction
+	c.NoError(json.Unmarshal(exported, &imported))
+
+	signature, err := SignTransaction(coldSigner, &imported)
+	c.NoError(err)
+
+	exportedSignature, err := json.Marshal(signature)
+	c.NoError(err)
+
+	// The online machine combines and submits it
+	var importedSignature TransactionSignature
+	c.NoError(json.Unmarshal(exportedSignature, &importedSignature))
+
+	input, err := CombineTransaction(unsignedTX, &importedSignature)
+	c.NoError(err)
+	c.Equal(coldSigner.GetAddress(), input.Address)
+
+	output, err := txBuilder.SubmitSigned(unsignedTX, &importedSignature)
+	c.NoError(err)
+
+	server.Commit()
+
+	tx, err := provider.NewProvider(server.URL, []string{server.URL}).GetTransaction(output.Txhash, nil)
+	c.NoError(err)
+	c.Zero(tx.TxResult.Code)
+	c.Equal("treasury", tx.StdTx.Memo)
+	c.Equal(int64(21), server.Balance(receiver.GetAddress()).Int64())
+}
+
+func TestTransactionBuilder_OfflineSigningErrors(t *testing.T) {
+	c := require.New(t)
+
+	coldSigner, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	otherSigner, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	txBuilder := NewTransactionBuilder(nil, nil)
+
+	_, err = txBuilder.CreateUnsignedTransaction(Mainnet, nil, nil)
+	c.Equal(ErrNoTransactionMessage, err)
+
+	msgSend, err := NewSend(coldSigner.GetAddress(), otherSigner.GetAddress(), 21)
+	c.NoError(err)
+
+	unsignedTX, err := txBuilder.CreateUnsignedTransaction(Mainnet, msgSend, nil)
+	c.NoError(err)
+
+	_, err = SignTransaction(nil, unsignedTX)
+	c.Equal(ErrNoSigner, err)
+
+	_, err = SignTransaction(coldSigner, nil)
+	c.Equal(ErrNoUnsignedTransaction, err)
+
+	signature, err := SignTransaction(coldSigner, unsignedTX)
+	c.NoError(err)
+
+	_, err = CombineTransaction(unsignedTX, nil)
+	c.Equal(ErrNoSignature, err)
+
+	// Fields changed after building do not match the sign bytes
+	tampered := *unsignedTX
+	tampered.Fee = 1
+
+	_, err = SignTransaction(coldSigner, &tampered)
+	c.Equal(ErrSignBytesMismatch, err)
+
+	_, err = CombineTransaction(&tampered, signature)
+	c.Equal(ErrSignBytesMismatch, err)
+
+	otherSignature, err := SignTransaction(otherSigner, unsignedTX)
+	c.NoError(err)
+
+	otherSignature.PublicKey = signature.PublicKey
+
+	_, err = CombineTransaction(unsignedTX, otherSignature)
+	c.Equal(ErrInvalidSignature, err)
+
+	_, err = txBuilder.SubmitSigned(unsignedTX, signature)
+	c.Equal(ErrNoProvider, err)
+}
@@ -0,0 +1,351 @@
+package transactionbuilder
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	coreTypes "github.com/pokt-network/pocket-core/types"
+	appsType "github.com/pokt-network/pocket-core/x/apps/types"
+	nodesTypes "github.com/pokt-network/pocket-core/x/nodes/types"
+)
+
+// PreflightRule enum that represents the chain state rules checked before signing a transaction
+type PreflightRule string
+
+const (
+	// BalanceRule the signer balance must cover the amount sent or staked plus the fee
+	BalanceRule PreflightRule = "balance"
+	// StakeMinimumRule the stake must reach the stake minimum param and not lower the current stake
+	StakeMinimumRule PreflightRule = "stake_minimum"
+	// SupportedChainsRule the staked chains must be supported by the network
+	SupportedChainsRule PreflightRule = "supported_chains"
+	// MaximumChainsRule the staked chains must not exceed the maximum chains param
+	MaximumChainsRule PreflightRule = "maximum_chains"
+	// StatusRule the app or node must exist and be in the status the message requires
+	StatusRule PreflightRule = "status"
+)
+
+// PreflightError error when a transaction would fail on chain, Rule tells which check failed
+type PreflightError struct {
+	Rule   PreflightRule
+	Reason string
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *PreflightError) Error() string {
+	return fmt.Sprintf("preflight %s check failed: %s", e.Rule, e.Reason)
+}
+
+// PreflightProvider interface representing provider functions necessary for Preflight
+type PreflightProvider interface {
+	GetBalanceWithCtx(ctx context.Context, address string, options *provider.GetBalanceOptions) (*big.Int, error)
+	GetAppWithCtx(ctx context.Context, address string, options *provider.GetAppOptions) (*provider.GetAppOutput, error)
+	GetNodeWithCtx(ctx context.Context, address string, options *provider.GetNodeOptions) (*provider.GetNodeOutput, error)
+	GetAllParamsWithCtx(ctx context.Context, options *provider.GetAllParamsOptions) (*provider.AllParams, error)
+	GetSupportedChainsWithCtx(ctx context.Context, options *provider.GetSupportedChainsOptions) ([]string, error)
+}
+
+// Preflight checks transactions against the chain state before signing them,
+// so transactions that could never succeed don't burn their fee
+type Preflight struct {
+	provider PreflightProvider
+}
+
+// NewPreflight returns an instance of Preflight
+func NewPreflight(provider PreflightProvider) *Preflight {
+	return &Preflight{provider: provider}
+}
+
+// stake represents the values of app and node stake messages checked by Preflight
+type stake struct {
+	minimumParam       string
+	maximumChainsParam string
+	value              *big.Int
+	chains             []string
+	currentTokens      string
+}
+
+// Check returns PreflightError when the transaction of the signer would fail with the current chain state
+func (p *Preflight) Check(signerAddress string, txMsg TransactionMessage, fee int64) error {
+	return p.CheckWithCtx(context.Background(), signerAddress, txMsg, fee)
+}
+
+// CheckWithCtx returns PreflightError when the transaction of the signer would fail with the current chain state
+func (p *Preflight) CheckWithCtx(ctx context.Context, signerAddress string, txMsg TransactionMessage, fee int64) error {
+	switch msg := txMsg.(type) {
+	case nil:
+		return ErrNoTransactionMessage
+	case *nodesTypes.MsgSend:
+		return p.checkBalance(ctx, signerAddress, msg.Amount.BigInt(), fee)
+	case *appsType.MsgStake:
+		return p.checkAppStake(ctx, signerAddress, msg, fee)
+	case *nodesTypes.MsgStake:
+		return p.checkNodeStake(ctx, signerAddress, msg, fee)
+	case *appsType.MsgBeginUnstake:
+		err := p.checkApp(ctx, msg.Address.String(), func(app *provider.App) string {
+			if app.Status != int(provider.Staked) {
+				return "is not staked"
+			}
+
+			if app.Jailed {
+				return "is jailed"
+			}
+
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+	case *appsType.MsgUnjail:
+		err := p.checkApp(ctx, msg.AppAddr.String(), func(app *provider.App) string {
+			if !app.Jailed {
+				return "is not jailed"
+			}
+
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+	case *nodesTypes.MsgBeginUnstake:
+		err := p.checkNode(ctx, msg.Address.String(), func(node *provider.Node) string {
+			if node.Status != int(provider.Staked) {
+				return "is not staked"
+			}
+
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+	case *nodesTypes.MsgUnjail:
+		err := p.checkNode(ctx, msg.ValidatorAddr.String(), func(node *provider.Node) string {
+			if !node.Jailed {
+				return "is not jailed"
+			}
+
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.checkBalance(ctx, signerAddress, new(big.Int), fee)
+}
+
+func (p *Preflight) checkBalance(ctx context.Context, address string, amount *big.Int, fee int64) error {
+	balance, err := p.provider.GetBalanceWithCtx(ctx, address, nil)
+	if err != nil {
+		return err
+	}
+
+	required := new(big.Int).Add(amount, big.NewInt(fee))
+
+	if balance.Cmp(required) < 0 {
+		return &PreflightError{
+			Rule:   BalanceRule,
+			Reason: fmt.Sprintf("balance of %s upokt does not cover %s upokt plus a fee of %d upokt", balance, amount, fee),
+		}
+	}
+
+	return nil
+}
+
+func (p *Preflight) checkAppStake(ctx context.Context, signerAddress string, msg *appsType.MsgStake, fee int64) error {
+	address := coreTypes.Address(msg.PubKey.Address()).String()
+
+	app, err := p.getApp(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	appStake := stake{
+		minimumParam:       "application/ApplicationStakeMinimum",
+		maximumChainsParam: "application/MaximumChains",
+		value:              msg.Value.BigInt(),
+		chains:             msg.Chains,
+	}
+
+	if app != nil {
+		if app.Status == int(provider.Unstaking) {
+			return statusError("app", address, "is unstaking")
+		}
+
+		appStake.currentTokens = app.StakedTokens
+	}
+
+	return p.checkStake(ctx, signerAddress, appStake, fee)
+}
+
+func (p *Preflight) checkNodeStake(ctx context.Context, signerAddress string, msg *nodesTypes.MsgStake, fee int64) error {
+	address := coreTypes.Address(msg.PublicKey.Address()).String()
+
+	node, err := p.getNode(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	nodeStake := stake{
+		minimumParam:       "pos/StakeMinimum",
+		maximumChainsParam: "pos/MaximumChains",
+		value:              msg.Value.BigInt(),
+		chains:             msg.Chains,
+	}
+
+	if node != nil {
+		if node.Status == int(provider.Unstaking) {
+			return statusError("node", address, "is unstaking")
+		}
+
+		nodeStake.currentTokens = node.Tokens
+	}
+
+	return p.checkStake(ctx, signerAddress, nodeStake, fee)
+}
+
+// checkStake checks the stake against the params and the supported chains,
+// the signer pays only the difference with the current stake
+func (p *Preflight) checkStake(ctx context.Context, signerAddress string, s stake, fee int64) error {
+	params, err := p.provider.GetAllParamsWithCtx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	minimum, ok := new(big.Int).SetString(getParam(params, s.minimumParam), 10)
+	if ok && s.value.Cmp(minimum) < 0 {
+		return &PreflightError{
+			Rule:   StakeMinimumRule,
+			Reason: fmt.Sprintf("stake of %s upokt is lower than the minimum of %s upokt", s.value, minimum),
+		}
+	}
+
+	amount := new(big.Int).Set(s.value)
+
+	current, ok := new(big.Int).SetString(s.currentTokens, 10)
+	if ok {
+		if s.value.Cmp(current) < 0 {
+			return &PreflightError{
+				Rule:   StakeMinimumRule,
+				Reason: fmt.Sprintf("stake of %s upokt is lower than the current stake of %s upokt", s.value, current),
+			}
+		}
+
+		amount.Sub(amount, current)
+	}
+
+	maximumChains, err := strconv.Atoi(getParam(params, s.maximumChainsParam))
+	if err == nil && len(s.chains) > maximumChains {
+		return &PreflightError{
+			Rule:   MaximumChainsRule,
+			Reason: fmt.Sprintf("%d chains staked, the maximum is %d", len(s.chains), maximumChains),
+		}
+	}
+
+	supportedChains, err := p.provider.GetSupportedChainsWithCtx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	supported := make(map[string]bool, len(supportedChains))
+	for _, chain := range supportedChains {
+		supported[chain] = true
+	}
+
+	for _, chain := range s.chains {
+		if !supported[chain] {
+			return &PreflightError{
+				Rule:   SupportedChainsRule,
+				Reason: fmt.Sprintf("chain %s is not supported by the network", chain),
+			}
+		}
+	}
+
+	return p.checkBalance(ctx, signerAddress, amount, fee)
+}
+
+// checkApp returns StatusRule error when the app does not exist or check returns a reason
+func (p *Preflight) checkApp(ctx context.Context, address string, check func(app *provider.App) string) error {
+	app, err := p.getApp(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	if app == nil {
+		return statusError("app", address, "does not exist")
+	}
+
+	if reason := check(app); reason != "" {
+		return statusError("app", address, reason)
+	}
+
+	return nil
+}
+
+// checkNode returns StatusRule error when the node does not exist or check returns a reason
+func (p *Preflight) checkNode(ctx context.Context, address string, check func(node *provider.Node) string) error {
+	node, err := p.getNode(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	if node == nil {
+		return statusError("node", address, "does not exist")
+	}
+
+	if reason := check(node); reason != "" {
+		return statusError("node", address, reason)
+	}
+
+	return nil
+}
+
+// getApp returns nil with no error when the app does not exist
+func (p *Preflight) getApp(ctx context.Context, address string) (*provider.App, error) {
+	output, err := p.provider.GetAppWithCtx(ctx, address, nil)
+	if provider.IsNotFoundError(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output.App, nil
+}
+
+// getNode returns nil with no error when the node does not exist
+func (p *Preflight) getNode(ctx context.Context, address string) (*provider.Node, error) {
+	output, err := p.provider.GetNodeWithCtx(ctx, address, nil)
+	if provider.IsNotFoundError(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Node, nil
+}
+
+func statusError(entity, address, reason string) *PreflightError {
+	return &PreflightError{
+		Rule:   StatusRule,
+		Reason: fmt.Sprintf("%s %s %s", entity, address, reason),
+	}
+}
+
+// getParam returns the value of the app or node param
+func getParam(params *provider.AllParams, key string) string {
+	if value, ok := params.AppParams.Get(key); ok {
+		return value
+	}
+
+	value, _ := params.NodeParams.Get(key)
+
+	return value
+}
@@ -0,0 +1,150 @@
+package transactionbuilder
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflight_Check(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	server.SetChainBackend("0021", http.NotFoundHandler())
+	server.SetChainBackend("0001", http.NotFoundHandler())
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	jailedApp, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	unstakingNode, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 2000000)
+	c.NoError(server.AddApp(provider.App{PublicKey: sender.GetPublicKey(), StakedTokens: "1500000", Chains: []string{"0021"}}))
+	c.NoError(server.AddApp(provider.App{PublicKey: jailedApp.GetPublicKey(), StakedTokens: "1000000", Jailed: true}))
+	c.NoError(server.AddNode(provider.Node{PublicKey: unstakingNode.GetPublicKey(), Tokens: "15000000000", Status: int(provider.Unstaking)}))
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	preflight := NewPreflight(rpcProvider)
+
+	newMsg := func(msg TransactionMessage, err error) TransactionMessage {
+		c.NoError(err)
+		return msg
+	}
+
+	tests := []struct {
+		name string
+		msg  TransactionMessage
+		rule PreflightRule
+	}{
+		{
+			name: "send covered by balance",
+			msg:  newMsg(NewSend(sender.GetAddress(), jailedApp.GetAddress(), 1990000)),
+		},
+		{
+			name: "send not covering the fee",
+			msg:  newMsg(NewSend(sender.GetAddress(), jailedApp.GetAddress(), 1995000)),
+			rule: BalanceRule,
+		},
+		{
+			name: "stake increase paying the difference",
+			msg:  newMsg(NewStakeApp(sender.GetPublicKey(), []string{"0021", "0001"}, 3000000)),
+		},
+		{
+			name: "stake increase not covered",
+			msg:  newMsg(NewStakeApp(sender.GetPublicKey(), []string{"0021"}, 4000000)),
+			rule: BalanceRule,
+		},
+		{
+			name: "stake lowered",
+			msg:  newMsg(NewStakeApp(sender.GetPublicKey(), []string{"0021"}, 1200000)),
+			rule: StakeMinimumRule,
+		},
+		{
+			name: "stake under minimum",
+			msg:  newMsg(NewStakeNode(jailedApp.GetPublicKey(), "https://node.example.com:443", "", []string{"0021"}, 1000000)),
+			rule: StakeMinimumRule,
+		},
+		{
+			name: "unsupported chain",
+			msg:  newMsg(NewStakeApp(sender.GetPublicKey(), []string{"0021", "0040"}, 1500000)),
+			rule: SupportedChainsRule,
+		},
+		{
+			name: "unstaking node restaked",
+			msg:  newMsg(NewStakeNode(unstakingNode.GetPublicKey(), "https://node.example.com:443", "", []string{"0021"}, 15000000000)),
+			rule: StatusRule,
+		},
+		{
+			name: "unstake staked app",
+			msg:  newMsg(NewUnstakeApp(sender.GetAddress())),
+		},
+		{
+			name: "unstake jailed app",
+			msg:  newMsg(NewUnstakeApp(jailedApp.GetAddress())),
+			rule: StatusRule,
+		},
+		{
+			name: "unjail jailed app",
+			msg:  newMsg(NewUnjailApp(jailedApp.GetAddress())),
+		},
+		{
+			name: "unjail app not jailed",
+			msg:  newMsg(NewUnjailApp(sender.GetAddress())),
+			rule: StatusRule,
+		},
+		{
+			name: "unstake unstaking node",
+			msg:  newMsg(NewUnstakeNode(sender.GetAddress(), unstakingNode.GetAddress())),
+			rule: StatusRule,
+		},
+		{
+			name: "unjail missing node",
+			msg:  newMsg(NewUnjailNode(sender.GetAddress(), sender.GetAddress())),
+			rule: StatusRule,
+		},
+	}
+
+	for _, tt := range tests {
+		err := preflight.Check(sender.GetAddress(), tt.msg, 10000)
+
+		if tt.rule == "" {
+			c.NoError(err, tt.name)
+			continue
+		}
+
+		var preflightErr *PreflightError
+		c.ErrorAs(err, &preflightErr, tt.name)
+		c.Equal(tt.rule, preflightErr.Rule, tt.name)
+	}
+
+	server.SetParam("application/MaximumChains", "1")
+
+	err = preflight.Check(sender.GetAddress(), newMsg(NewStakeApp(sender.GetPublicKey(), []string{"0021", "0001"}, 1500000)), 10000)
+	c.ErrorContains(err, "preflight maximum_chains check failed: 2 chains staked, the maximum is 1")
+
+	err = preflight.Check(sender.GetAddress(), nil, 10000)
+	c.Equal(ErrNoTransactionMessage, err)
+
+	// Failing transactions are not signed nor sent
+	server.SetBalance(jailedApp.GetAddress(), 10000)
+
+	txBuilder := NewTransactionBuilder(rpcProvider, jailedApp)
+	txBuilder.SetPreflightChecker(preflight)
+
+	_, err = txBuilder.Submit(Localnet, newMsg(NewUnjailApp(sender.GetAddress())), nil)
+	c.ErrorContains(err, "is not jailed")
+
+	output, err := txBuilder.Submit(Localnet, newMsg(NewUnjailApp(jailedApp.GetAddress())), nil)
+	c.NoError(err)
+	c.NotEmpty(output.Txhash)
+}
@@ -0,0 +1,282 @@
+package transactionbuilder
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/utils"
+)
+
+const (
+	defaultMaxInFlight     = 10
+	defaultMaxRetries      = 3
+	defaultQueueMinBackoff = time.Second
+	defaultQueueMaxBackoff = 30 * time.Second
+)
+
+// ErrNoTransactionBuilder error when no transaction builder is provided
+var ErrNoTransactionBuilder = errors.New("no transaction builder provided")
+
+// QueueConfig represents the config of a Queue
+type QueueConfig struct {
+	ChainID ChainID
+	// MaxInFlight is the amount of transactions submitted at once, defaults to 10
+	MaxInFlight int
+	// MaxRetries is the amount of times a transaction is sent again after transient errors, defaults to 3
+	MaxRetries int
+	// MinBackoff and MaxBackoff bound the exponential wait between retries, default to 1 second and 30 seconds
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// WaitOptions makes every transaction wait until it is included in a block when set
+	// the provider of the TransactionBuilder must implement TransactionTracker then
+	WaitOptions *WaitOptions
+	// OnResult is called with the result of every message as it finishes
+	OnResult func(result *QueueResult)
+}
+
+// QueueResult represents the outcome of a message or signed transaction submitted by a Queue
+type QueueResult struct {
+	// Index is the position of the message or signed transaction in the submitted ones
+	Index int
+	// Message is nil for signed transactions
+	Message TransactionMessage
+	// Hash is set once the transaction is signed, it can be tracked even when Err is set
+	Hash     string
+	Attempts int
+	// Transaction is set when the Queue waits for inclusion
+	Transaction *provider.Transaction
+	Err         error
+}
+
+// Queue submits many messages concurrently with a limit of transactions in flight, retrying transient errors
+// Retries send the same signed transaction, so a transaction that reached the node is never paid twice
+// It is safe for concurrent use, the limit is shared by all calls
+type Queue struct {
+	txBuilder   *TransactionBuilder
+	chainID     ChainID
+	maxRetries  int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	waitOptions *WaitOptions
+	onResult    func(result *QueueResult)
+	slots       chan struct{}
+	resultMu    sync.Mutex
+}
+
+// NewQueue returns an instance of Queue submitting with the transaction builder
+func NewQueue(txBuilder *TransactionBuilder, config QueueConfig) (*Queue, error) {
+	if txBuilder == nil {
+		return nil, ErrNoTransactionBuilder
+	}
+
+	if config.ChainID == "" {
+		return nil, ErrNoChainID
+	}
+
+	queue := &Queue{
+		txBuilder:   txBuilder,
+		chainID:     config.ChainID,
+		maxRetries:  config.MaxRetries,
+		minBackoff:  config.MinBackoff,
+		maxBackoff:  config.MaxBackoff,
+		waitOptions: config.WaitOptions,
+		onResult:    config.OnResult,
+	}
+
+	maxInFlight := config.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = defaultMaxInFlight
+	}
+
+	queue.slots = make(chan struct{}, maxInFlight)
+
+	if queue.maxRetries <= 0 {
+		queue.maxRetries = defaultMaxRetries
+	}
+
+	if queue.minBackoff <= 0 {
+		queue.minBackoff = defaultQueueMinBackoff
+	}
+
+	if queue.maxBackoff <= 0 {
+		queue.maxBackoff = defaultQueueMaxBackoff
+	}
+
+	if queue.maxBackoff < queue.minBackoff {
+		queue.maxBackoff = queue.minBackoff
+	}
+
+	return queue, nil
+}
+
+// Submit signs and submits the messages, returns their results in the same order
+func (q *Queue) Submit(txMsgs []TransactionMessage, options *TransactionOptions) []*QueueResult {
+	return q.SubmitWithCtx(context.Background(), txMsgs, options)
+}
+
+// SubmitWithCtx signs and submits the messages, returns their results in the same order
+// Messages are started in order, the ones not started when the context is done fail with its error
+func (q *Queue) SubmitWithCtx(ctx context.Context, txMsgs []TransactionMessage, options *TransactionOptions) []*QueueResult {
+	results := make([]*QueueResult, len(txMsgs))
+	for i, txMsg := range txMsgs {
+		results[i] = &QueueResult{Index: i, Message: txMsg}
+	}
+
+	q.run(ctx, results, func(result *QueueResult) {
+		input, err := q.txBuilder.CreateTransactionWithCtx(ctx, q.chainID, result.Message, options)
+		if err != nil {
+			result.Err = err
+			return
+		}
+
+		q.deliver(ctx, result, input, false)
+	})
+
+	return results
+}
+
+// Send submits transactions already signed, returns their results in the same order
+func (q *Queue) Send(inputs []*provider.SendTransactionInput) []*QueueResult {
+	return q.SendWithCtx(context.Background(), inputs)
+}
+
+// SendWithCtx submits transactions already signed, returns their results in the same order
+// The transactions may have been sent before, the ones already known by the node count as sent
+func (q *Queue) SendWithCtx(ctx context.Context, inputs []*provider.SendTransactionInput) []*QueueResult {
+	results := make([]*QueueResult, len(inputs))
+	for i := range inputs {
+		results[i] = &QueueResult{Index: i}
+	}
+
+	q.run(ctx, results, func(result *QueueResult) {
+		q.deliver(ctx, result, inputs[result.Index], true)
+	})
+
+	return results
+}
+
+// run processes the results in order with the limit of transactions in flight
+func (q *Queue) run(ctx context.Context, results []*QueueResult, process func(result *QueueResult)) {
+	var wg sync.WaitGroup
+
+	for _, result := range results {
+		if err := q.acquire(ctx); err != nil {
+			result.Err = err
+			q.report(result)
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(result *QueueResult) {
+			defer wg.Done()
+
+			process(result)
+			<-q.slots
+
+			q.report(result)
+		}(result)
+	}
+
+	wg.Wait()
+}
+
+// acquire waits for a transaction in flight to finish, failing when the context is done even if there is room
+func (q *Queue) acquire(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	select {
+	case q.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) report(result *QueueResult) {
+	if q.onResult == nil {
+		return
+	}
+
+	q.resultMu.Lock()
+	defer q.resultMu.Unlock()
+
+	q.onResult(result)
+}
+
+// deliver sends the signed transaction until it succeeds or fails permanently, known tells whether
+// it may have been sent before
+func (q *Queue) deliver(ctx context.Context, result *QueueResult, input *provider.SendTransactionInput, known bool) {
+	txBytes, err := hex.DecodeString(input.RawHexBytes)
+	if err != nil {
+		result.Err = err
+		return
+	}
+
+	result.Hash = transactionHash(txBytes)
+
+	for backoff := q.minBackoff; ; backoff = utils.NextBackoff(backoff, q.maxBackoff) {
+		result.Attempts++
+
+		err = q.send(ctx, result, input, known || result.Attempts > 1)
+		if err == nil || !isTransient(err) || result.Attempts > q.maxRetries {
+			break
+		}
+
+		if err = utils.Sleep(ctx, backoff); err != nil {
+			break
+		}
+	}
+
+	if err != nil || q.waitOptions == nil {
+		result.Err = err
+		return
+	}
+
+	result.Transaction, result.Err = q.txBuilder.WaitForTransactionWithCtx(ctx, result.Hash, q.waitOptions)
+}
+
+// send sends the transaction, when it may have been sent before a transaction already known by the node counts as sent
+func (q *Queue) send(ctx context.Context, result *QueueResult, input *provider.SendTransactionInput, known bool) error {
+	if tracker, ok := q.txBuilder.provider.(TransactionTracker); ok && known {
+		transaction, err := tracker.GetTransactionWithCtx(ctx, result.Hash, nil)
+		if err == nil && transaction.Transaction != nil {
+			_, err = CheckTransactionResult(transaction.Transaction)
+			return err
+		}
+	}
+
+	_, err := q.txBuilder.sendTransaction(ctx, input)
+	if known && errors.Is(err, ErrDuplicateTransaction) {
+		return nil
+	}
+
+	return err
+}
+
+// isTransient returns whether sending the same transaction again may succeed,
+// including responses dropped after the node may have received the transaction
+func isTransient(err error) bool {
+	if errors.Is(err, provider.Err5xxOnConnection) || errors.Is(err, ErrNodeInternal) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var rpcErr *provider.RPCError
+	if errors.As(err, &rpcErr) {
+		return strings.Contains(strings.ToLower(rpcErr.Message), "mempool is full")
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
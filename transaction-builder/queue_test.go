@@ -0,0 +1,161 @@
+package transactionbuilder
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue_Submit(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	receiver, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 1000000)
+
+	transport := pockettest.NewFaultTransport(nil)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{Timeout: time.Second, Transport: transport})
+
+	txBuilder := NewTransactionBuilder(rpcProvider, sender)
+
+	var reported []int
+
+	queue, err := NewQueue(txBuilder, QueueConfig{
+		ChainID:     Localnet,
+		MaxInFlight: 2,
+		MaxRetries:  2,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		OnResult: func(result *QueueResult) {
+			reported = append(reported, result.Index)
+		},
+	})
+	c.NoError(err)
+
+	txMsgs := make([]TransactionMessage, 5)
+	for i := range txMsgs {
+		txMsgs[i], err = NewSend(sender.GetAddress(), receiver.GetAddress(), int64(i+1))
+		c.NoError(err)
+	}
+
+	results := queue.Submit(txMsgs, nil)
+	c.Len(results, 5)
+	c.Len(reported, 5)
+
+	hashes := map[string]bool{}
+
+	for i, result := range results {
+		c.NoError(result.Err)
+		c.Equal(i, result.Index)
+		c.Equal(1, result.Attempts)
+		c.NotEmpty(result.Hash)
+
+		hashes[result.Hash] = true
+	}
+
+	// Every message is signed with fresh entropy
+	c.Len(hashes, 5)
+
+	server.Commit()
+	c.Equal(int64(15), server.Balance(receiver.GetAddress()).Int64())
+
+	// Responses dropped after the node accepted the transaction are retried without paying twice
+	transport.AddFault(pockettest.Fault{Route: provider.ClientRawTXRoute, Times: 1, TruncateAt: 10})
+
+	results = queue.Submit(txMsgs[:1], nil)
+	c.NoError(results[0].Err)
+	c.Equal(2, results[0].Attempts)
+
+	// Transactions still in the mempool when sent again are answered with the cache error, they count as sent too
+	transport.AddFault(pockettest.Fault{Route: provider.ClientRawTXRoute, Times: 1, TruncateAt: 10})
+	transport.AddFault(pockettest.Fault{
+		Route:      provider.ClientRawTXRoute,
+		Times:      1,
+		StatusCode: http.StatusBadRequest,
+		Body:       `{"code":400,"message":"tx already exists in cache"}`,
+	})
+
+	results = queue.Submit(txMsgs[2:3], nil)
+	c.NoError(results[0].Err)
+	c.Equal(2, results[0].Attempts)
+
+	// Node errors are retried until they stop
+	transport.AddFault(pockettest.Fault{Route: provider.ClientRawTXRoute, Times: 2, StatusCode: http.StatusServiceUnavailable})
+
+	results = queue.Submit(txMsgs[1:2], nil)
+	c.NoError(results[0].Err)
+	c.Equal(3, results[0].Attempts)
+
+	server.Commit()
+	c.Equal(int64(21), server.Balance(receiver.GetAddress()).Int64())
+	c.Equal(int64(1000000-21-8*10000), server.Balance(sender.GetAddress()).Int64())
+
+	// Retries are bounded
+	transport.AddFault(pockettest.Fault{Route: provider.ClientRawTXRoute, StatusCode: http.StatusServiceUnavailable})
+
+	results = queue.Submit(txMsgs[:1], nil)
+	c.ErrorIs(results[0].Err, provider.Err5xxOnConnection)
+	c.Equal(3, results[0].Attempts)
+
+	transport.Reset()
+
+	// Failures of the transaction itself are not retried
+	tooMuch, err := NewSend(sender.GetAddress(), receiver.GetAddress(), 10000000)
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 5000)
+
+	results = queue.Submit([]TransactionMessage{tooMuch}, nil)
+	c.ErrorIs(results[0].Err, ErrInsufficientFunds)
+	c.Equal(1, results[0].Attempts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results = queue.SubmitWithCtx(ctx, txMsgs, nil)
+	c.Len(results, 5)
+
+	for _, result := range results {
+		c.ErrorIs(result.Err, context.Canceled)
+	}
+
+	// Signed transactions sent again are not paid twice
+	server.SetBalance(sender.GetAddress(), 100000)
+
+	input, err := txBuilder.CreateTransaction(Localnet, txMsgs[4], nil)
+	c.NoError(err)
+
+	results = queue.Send([]*provider.SendTransactionInput{input, input})
+	c.NoError(results[0].Err)
+	c.NoError(results[1].Err)
+	c.Equal(results[0].Hash, results[1].Hash)
+	c.Nil(results[0].Message)
+
+	server.Commit()
+
+	results = queue.Send([]*provider.SendTransactionInput{input})
+	c.NoError(results[0].Err)
+	c.Equal(1, results[0].Attempts)
+	c.Equal(int64(100000-5-10000), server.Balance(sender.GetAddress()).Int64())
+
+	_, err = NewQueue(nil, QueueConfig{ChainID: Localnet})
+	c.Equal(ErrNoTransactionBuilder, err)
+
+	_, err = NewQueue(txBuilder, QueueConfig{})
+	c.Equal(ErrNoChainID, err)
+}
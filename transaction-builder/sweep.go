@@ -0,0 +1,127 @@
+package transactionbuilder
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+const maxSweepAttempts = 3
+
+var (
+	// ErrNothingToSweep error when the balance of the signer does not cover the fee of the sweep
+	ErrNothingToSweep = errors.New("balance does not cover the fee of the sweep")
+	// ErrNoBalanceProvider error when the provider does not implement BalanceProvider
+	ErrNoBalanceProvider = errors.New("provider can not get balances")
+)
+
+// BalanceProvider interface implemented by Providers that return the balance of an account
+// needed to sweep accounts
+type BalanceProvider interface {
+	GetBalanceWithCtx(ctx context.Context, address string, options *provider.GetBalanceOptions) (*big.Int, error)
+}
+
+// SweepOutput represents the included transaction sending the balance of the signer, Amount is the upokt sent
+type SweepOutput struct {
+	Transaction *provider.Transaction
+	Amount      int64
+	Fee         int64
+}
+
+// Sweep sends the whole balance of the signer minus the fee to the address and waits until it is included in a block
+func (t *TransactionBuilder) Sweep(chainID ChainID, toAddress string, options *TransactionOptions, waitOptions *WaitOptions) (*SweepOutput, error) {
+	return t.SweepWithCtx(context.Background(), chainID, toAddress, options, waitOptions)
+}
+
+// SweepWithCtx sends the whole balance of the signer minus the fee to the address and waits until it is included in a block
+// When the balance drops before the transaction is included it fails for insufficient funds, the balance is read
+// again and swept up to 3 times. Tokens received after the balance is read stay in the account, sweep again to send them
+// The provider of the builder must implement BalanceProvider and TransactionTracker
+func (t *TransactionBuilder) SweepWithCtx(ctx context.Context, chainID ChainID, toAddress string, options *TransactionOptions, waitOptions *WaitOptions) (*SweepOutput, error) {
+	if t.signer == nil {
+		return nil, ErrNoSigner
+	}
+
+	var err error
+
+	for attempt := 0; attempt < maxSweepAttempts; attempt++ {
+		var output *SweepOutput
+
+		output, err = t.sweep(ctx, chainID, toAddress, options, waitOptions)
+		if err == nil {
+			return output, nil
+		}
+
+		if !isBalanceChange(err) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+func (t *TransactionBuilder) sweep(ctx context.Context, chainID ChainID, toAddress string, options *TransactionOptions, waitOptions *WaitOptions) (*SweepOutput, error) {
+	if t.provider == nil {
+		return nil, ErrNoProvider
+	}
+
+	balanceProvider, ok := t.provider.(BalanceProvider)
+	if !ok {
+		return nil, ErrNoBalanceProvider
+	}
+
+	fromAddress := t.signer.GetAddress()
+
+	balance, err := balanceProvider.GetBalanceWithCtx(ctx, fromAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fee depends on the message type only, so it is computed with the whole balance
+	txMsg, err := NewSend(fromAddress, toAddress, balance.Int64())
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := t.transactionFee(ctx, txMsg, options)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := balance.Int64() - fee
+	if amount <= 0 {
+		return nil, ErrNothingToSweep
+	}
+
+	txMsg, err = NewSend(fromAddress, toAddress, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	sweepOptions := &TransactionOptions{Fee: fee}
+
+	if options != nil {
+		sweepOptions.Memo = options.Memo
+		sweepOptions.CoinDenom = options.CoinDenom
+	}
+
+	transaction, err := t.SubmitAndWaitWithCtx(ctx, chainID, txMsg, sweepOptions, waitOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SweepOutput{Transaction: transaction, Amount: amount, Fee: fee}, nil
+}
+
+// isBalanceChange returns whether the error means the balance dropped after it was read
+// Transactions failing on chain unwrap to ErrInsufficientFunds too
+func isBalanceChange(err error) bool {
+	var preflightErr *PreflightError
+	if errors.As(err, &preflightErr) {
+		return preflightErr.Rule == BalanceRule
+	}
+
+	return errors.Is(err, ErrInsufficientFunds)
+}
@@ -0,0 +1,98 @@
+package transactionbuilder
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+type balanceProviderMock struct {
+	*provider.Provider
+	onBalance func()
+}
+
+func (p *balanceProviderMock) GetBalanceWithCtx(ctx context.Context, address string, options *provider.GetBalanceOptions) (*big.Int, error) {
+	balance, err := p.Provider.GetBalanceWithCtx(ctx, address, options)
+
+	if p.onBalance != nil {
+		p.onBalance()
+	}
+
+	return balance, err
+}
+
+func TestTransactionBuilder_Sweep(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{AutoCommit: true})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	receiver, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	rpcProvider := &balanceProviderMock{Provider: provider.NewProvider(server.URL, []string{server.URL})}
+
+	txBuilder := NewTransactionBuilder(rpcProvider, sender)
+	txBuilder.SetFeeCalculator(NewFeeSchedule(rpcProvider))
+
+	waitOptions := &WaitOptions{PollInterval: 10 * time.Millisecond}
+
+	server.SetBalance(sender.GetAddress(), 10000)
+
+	_, err = txBuilder.Sweep(Localnet, receiver.GetAddress(), nil, waitOptions)
+	c.Equal(ErrNothingToSweep, err)
+
+	server.SetBalance(sender.GetAddress(), 1000000)
+
+	output, err := txBuilder.Sweep(Localnet, receiver.GetAddress(), &TransactionOptions{Memo: "rotate"}, waitOptions)
+	c.NoError(err)
+	c.Equal(int64(990000), output.Amount)
+	c.Equal(int64(10000), output.Fee)
+	c.NotEmpty(output.Transaction.Hash)
+	c.Zero(server.Balance(sender.GetAddress()).Int64())
+	c.Equal(int64(990000), server.Balance(receiver.GetAddress()).Int64())
+
+	// A balance dropping after it is read fails the sweep, it is swept again with the new balance
+	server.SetBalance(sender.GetAddress(), 500000)
+
+	drained := false
+	rpcProvider.onBalance = func() {
+		if !drained {
+			drained = true
+			server.SetBalance(sender.GetAddress(), 300000)
+		}
+	}
+
+	output, err = txBuilder.Sweep(Localnet, receiver.GetAddress(), nil, waitOptions)
+	c.NoError(err)
+	c.Equal(int64(280000), output.Amount)
+	c.Zero(server.Balance(sender.GetAddress()).Int64())
+	c.Equal(int64(1270000), server.Balance(receiver.GetAddress()).Int64())
+
+	// Retries are bounded when the balance keeps dropping
+	server.SetBalance(sender.GetAddress(), 500000)
+
+	balance := int64(500000)
+	rpcProvider.onBalance = func() {
+		balance -= 50000
+		server.SetBalance(sender.GetAddress(), balance)
+	}
+
+	_, err = txBuilder.Sweep(Localnet, receiver.GetAddress(), nil, waitOptions)
+	c.ErrorIs(err, ErrInsufficientFunds)
+
+	_, err = NewTransactionBuilder(rpcProvider, nil).Sweep(Localnet, receiver.GetAddress(), nil, waitOptions)
+	c.Equal(ErrNoSigner, err)
+
+	_, err = NewTransactionBuilder(struct{ Provider }{rpcProvider}, sender).Sweep(Localnet, receiver.GetAddress(), nil, waitOptions)
+	c.Equal(ErrNoBalanceProvider, err)
+}
@@ -4,18 +4,10 @@ package transactionbuilder
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/hex"
 	"errors"
-	"math"
-	"math/big"
+	"fmt"
 
 	"github.com/pokt-foundation/pocket-go/provider"
-	"github.com/pokt-network/pocket-core/app"
-	"github.com/pokt-network/pocket-core/crypto"
-	coreTypes "github.com/pokt-network/pocket-core/types"
-	"github.com/pokt-network/pocket-core/x/auth"
-	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
 )
 
 const defaultTXFee = int64(10000)
@@ -51,6 +43,14 @@ var (
 	ErrNoChainID = errors.New("no chain id provided")
 	// ErrNoTransactionMessage error when no Transaction Message is provided
 	ErrNoTransactionMessage = errors.New("no transaction message provided")
+	// ErrNonPositiveAmount error when a message amount is zero or negative
+	ErrNonPositiveAmount = errors.New("amount must be greater than zero")
+	// ErrNoTransactionTracker error when the provider does not implement TransactionTracker
+	ErrNoTransactionTracker = errors.New("provider can not track transactions")
+	// ErrInvalidParamKey error when a param key is not in the form module/Param
+	ErrInvalidParamKey = errors.New("param key must be in the form module/Param")
+	// ErrInvalidParamValue error when a param value is not valid JSON
+	ErrInvalidParamValue = errors.New("param value must be valid JSON")
 )
 
 // Provider interface representing provider functions necessary for Transaction Builder Package
@@ -58,6 +58,13 @@ type Provider interface {
 	SendTransactionWithCtx(ctx context.Context, input *provider.SendTransactionInput) (*provider.SendTransactionOutput, error)
 }
 
+// TransactionTracker interface implemented by Providers that follow the transactions after they are sent
+// needed to wait for transactions
+type TransactionTracker interface {
+	GetTransactionWithCtx(ctx context.Context, transactionHash string, options *provider.GetTransactionOptions) (*provider.GetTransactionOutput, error)
+	GetBlockHeightWithCtx(ctx context.Context) (int, error)
+}
+
 // Signer interface representing signer functions necessary for Transaction Builder package
 type Signer interface {
 	SignBytes(payload []byte) ([]byte, error)
@@ -65,10 +72,28 @@ type Signer interface {
 	GetPublicKey() string
 }
 
+// ChainRegistry interface representing chain registry functions necessary for Transaction Builder package
+type ChainRegistry interface {
+	ValidateChains(chains []string) error
+}
+
+// FeeCalculator interface representing fee functions necessary for Transaction Builder package
+type FeeCalculator interface {
+	RequiredFeeWithCtx(ctx context.Context, txMsg TransactionMessage) (int64, error)
+}
+
+// PreflightChecker interface representing chain state checks necessary for Transaction Builder package
+type PreflightChecker interface {
+	CheckWithCtx(ctx context.Context, signerAddress string, txMsg TransactionMessage, fee int64) error
+}
+
 // TransactionBuilder represents implementation of transaction builder package
 type TransactionBuilder struct {
-	provider Provider
-	signer   Signer
+	provider         Provider
+	signer           Signer
+	chainRegistry    ChainRegistry
+	feeCalculator    FeeCalculator
+	preflightChecker PreflightChecker
 }
 
 // TransactionOptions represents optional parameters for transaction request
@@ -86,6 +111,22 @@ func NewTransactionBuilder(provider Provider, signer Signer) *TransactionBuilder
 	}
 }
 
+// SetChainRegistry sets the registry used to reject stake transactions with unknown relay chains
+func (t *TransactionBuilder) SetChainRegistry(chainRegistry ChainRegistry) {
+	t.chainRegistry = chainRegistry
+}
+
+// SetFeeCalculator sets the calculator of the fees required by the network, i.e. a FeeSchedule
+// Transactions without fee pay the required one and transactions paying less are rejected
+func (t *TransactionBuilder) SetFeeCalculator(feeCalculator FeeCalculator) {
+	t.feeCalculator = feeCalculator
+}
+
+// SetPreflightChecker sets the checks against the chain state done before signing, i.e. a Preflight
+func (t *TransactionBuilder) SetPreflightChecker(preflightChecker PreflightChecker) {
+	t.preflightChecker = preflightChecker
+}
+
 func getOptionalParams(options *TransactionOptions) (string, string, int64) {
 	memo := ""
 	coinDenom := Upokt
@@ -115,75 +156,79 @@ func (t *TransactionBuilder) validateTransactionRequest(chainID ChainID, txMsg T
 		return ErrNoSigner
 	}
 
-	if chainID == "" {
-		return ErrNoChainID
-	}
-
-	if txMsg == nil {
-		return ErrNoTransactionMessage
-	}
-
-	return nil
+	return t.validateTransactionMessage(chainID, txMsg)
 }
 
-func (t *TransactionBuilder) signTransaction(chainID, memo, coinDenom string, fee int64, txMsg TransactionMessage) (string, error) {
-	feeStruct := coreTypes.Coins{
-		coreTypes.Coin{
-			Amount: coreTypes.NewInt(fee),
-			Denom:  coinDenom,
-		},
-	}
+// transactionFee returns the fee to pay, the required one when there is a fee calculator and no fee is given
+func (t *TransactionBuilder) transactionFee(ctx context.Context, txMsg TransactionMessage, options *TransactionOptions) (int64, error) {
+	_, _, fee := getOptionalParams(options)
 
-	entropy, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
-	if err != nil {
-		return "", err
+	if t.feeCalculator == nil {
+		return fee, nil
 	}
 
-	signBytes, err := auth.StdSignBytes(chainID, entropy.Int64(), feeStruct, txMsg, memo)
+	requiredFee, err := t.feeCalculator.RequiredFeeWithCtx(ctx, txMsg)
 	if err != nil {
-		return "", err
+		return 0, err
 	}
 
-	signature, err := t.signer.SignBytes(signBytes)
-	if err != nil {
-		return "", err
+	if options == nil || options.Fee == 0 {
+		return requiredFee, nil
 	}
 
-	publicKey, err := crypto.NewPublicKey(t.signer.GetPublicKey())
-	if err != nil {
-		return "", err
+	if options.Fee < requiredFee {
+		return 0, &InsufficientFeeError{MessageType: txMsg.Type(), Required: requiredFee, Provided: options.Fee}
 	}
 
-	signatureStruct := authTypes.StdSignature{PublicKey: publicKey, Signature: signature}
+	return options.Fee, nil
+}
+
+func (t *TransactionBuilder) validateTransactionMessage(chainID ChainID, txMsg TransactionMessage) error {
+	if chainID == "" {
+		return ErrNoChainID
+	}
 
-	tx := authTypes.NewTx(txMsg, feeStruct, signatureStruct, memo, entropy.Int64())
+	if txMsg == nil {
+		return ErrNoTransactionMessage
+	}
 
-	txBytes, err := auth.DefaultTxEncoder(app.Codec())(tx, -1)
-	if err != nil {
-		return "", err
+	if t.chainRegistry != nil {
+		return t.chainRegistry.ValidateChains(stakedChains(txMsg))
 	}
 
-	return hex.EncodeToString(txBytes), nil
+	return nil
 }
 
 // CreateTransaction returns input necessary for doing a transaction
 func (t *TransactionBuilder) CreateTransaction(chainID ChainID, txMsg TransactionMessage, options *TransactionOptions) (*provider.SendTransactionInput, error) {
+	return t.CreateTransactionWithCtx(context.Background(), chainID, txMsg, options)
+}
+
+// CreateTransactionWithCtx returns input necessary for doing a transaction
+func (t *TransactionBuilder) CreateTransactionWithCtx(ctx context.Context, chainID ChainID, txMsg TransactionMessage, options *TransactionOptions) (*provider.SendTransactionInput, error) {
 	err := t.validateTransactionRequest(chainID, txMsg)
 	if err != nil {
 		return nil, err
 	}
 
-	memo, coinDenom, fee := getOptionalParams(options)
+	unsignedTX, err := t.CreateUnsignedTransactionWithCtx(ctx, chainID, txMsg, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.preflightChecker != nil {
+		err = t.preflightChecker.CheckWithCtx(ctx, t.signer.GetAddress(), txMsg, unsignedTX.Fee)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	signedTX, err := t.signTransaction(string(chainID), memo, coinDenom, fee, txMsg)
+	signature, err := SignTransaction(t.signer, unsignedTX)
 	if err != nil {
 		return nil, err
 	}
 
-	return &provider.SendTransactionInput{
-		Address:     t.signer.GetAddress(),
-		RawHexBytes: signedTX,
-	}, nil
+	return CombineTransaction(unsignedTX, signature)
 }
 
 // Submit does the transaction from raw input
@@ -192,11 +237,35 @@ func (t *TransactionBuilder) Submit(chainID ChainID, txMsg TransactionMessage, o
 }
 
 // SubmitWithCtx does the transaction from raw input
+// Returns TransactionRejectedError when the node rejects the transaction
 func (t *TransactionBuilder) SubmitWithCtx(ctx context.Context, chainID ChainID, txMsg TransactionMessage, options *TransactionOptions) (*provider.SendTransactionOutput, error) {
-	sendTransactionInput, err := t.CreateTransaction(chainID, txMsg, options)
+	sendTransactionInput, err := t.CreateTransactionWithCtx(ctx, chainID, txMsg, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.sendTransaction(ctx, sendTransactionInput)
+}
+
+// sendTransaction returns TransactionRejectedError when the node responds with a non zero code
+func (t *TransactionBuilder) sendTransaction(ctx context.Context, input *provider.SendTransactionInput) (*provider.SendTransactionOutput, error) {
+	output, err := t.provider.SendTransactionWithCtx(ctx, input)
+	if isTransactionInCache(err) {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateTransaction, err)
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	return t.provider.SendTransactionWithCtx(ctx, sendTransactionInput)
+	if output.Code != 0 {
+		return nil, &TransactionRejectedError{
+			Hash:      output.Txhash,
+			Code:      output.Code,
+			Codespace: output.Codespace,
+			Log:       output.RawLog,
+		}
+	}
+
+	return output, nil
 }
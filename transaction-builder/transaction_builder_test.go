@@ -8,6 +8,7 @@ import (
 
 	"github.com/jarcoal/httpmock"
 	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/relaychain"
 	"github.com/pokt-foundation/pocket-go/signer"
 	"github.com/pokt-foundation/utils-go/mock-client"
 	"github.com/stretchr/testify/require"
@@ -492,3 +493,43 @@ func TestTransactionBuilder_SubmitUnjailNodeWithCtx(t *testing.T) {
 	c.Empty(output)
 	c.Equal(provider.Err5xxOnConnection, err)
 }
+
+func TestTransactionBuilder_ChainRegistry(t *testing.T) {
+	c := require.New(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	signer, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	registry, err := relaychain.NewRegistry(relaychain.Chain{ID: "0001"}, relaychain.Chain{ID: "0021"})
+	c.NoError(err)
+
+	txBuilder := NewTransactionBuilder(provider.NewProvider("https://dummy.com", []string{"https://dummy.com"}), signer)
+	txBuilder.SetChainRegistry(registry)
+
+	mock.AddMockedResponseFromFile(http.MethodPost, fmt.Sprintf("%s%s", "https://dummy.com", provider.ClientRawTXRoute),
+		http.StatusOK, "../provider/samples/client_raw_tx.json")
+
+	stakeApp, err := NewStakeApp(signer.GetPublicKey(), []string{"0001", "0021"}, 21)
+	c.NoError(err)
+
+	output, err := txBuilder.Submit(Mainnet, stakeApp, nil)
+	c.NoError(err)
+	c.NotEmpty(output)
+
+	stakeNode, err := NewStakeNode(signer.GetPublicKey(), "https://dummy.com:443", signer.GetAddress(), []string{"0021", "0009"}, 21)
+	c.NoError(err)
+
+	output, err = txBuilder.Submit(Mainnet, stakeNode, nil)
+	c.ErrorIs(err, relaychain.ErrUnknownChain)
+	c.Empty(output)
+
+	// Messages without chains are not affected
+	unstakeApp, err := NewUnstakeApp(signer.GetAddress())
+	c.NoError(err)
+
+	_, err = txBuilder.Submit(Mainnet, unstakeApp, nil)
+	c.NoError(err)
+}
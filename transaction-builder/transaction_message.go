@@ -2,10 +2,14 @@ package transactionbuilder
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"strings"
 
+	"github.com/pokt-network/pocket-core/app"
 	"github.com/pokt-network/pocket-core/crypto"
 	coreTypes "github.com/pokt-network/pocket-core/types"
 	appsType "github.com/pokt-network/pocket-core/x/apps/types"
+	govTypes "github.com/pokt-network/pocket-core/x/gov/types"
 	nodesTypes "github.com/pokt-network/pocket-core/x/nodes/types"
 )
 
@@ -127,3 +131,135 @@ func NewUnjailNode(fromAddress, operatorAddress string) (TransactionMessage, err
 		Signer:        decodedFromAddress,
 	}, nil
 }
+
+// NewDAOTransfer returns message for DAO Transfer transaction, moving tokens from the DAO to the address
+// Only the DAO owner can sign it
+func NewDAOTransfer(fromAddress, toAddress string, amount int64) (TransactionMessage, error) {
+	decodedToAddress, err := decodeAddress(toAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDAOAction(fromAddress, decodedToAddress, amount, govTypes.DAOTransferString)
+}
+
+// NewDAOBurn returns message for DAO Burn transaction, burning tokens of the DAO
+// Only the DAO owner can sign it
+func NewDAOBurn(fromAddress string, amount int64) (TransactionMessage, error) {
+	return newDAOAction(fromAddress, nil, amount, govTypes.DAOBurnString)
+}
+
+func newDAOAction(fromAddress string, toAddress coreTypes.Address, amount int64, action string) (TransactionMessage, error) {
+	decodedFromAddress, err := decodeAddress(fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount <= 0 {
+		return nil, ErrNonPositiveAmount
+	}
+
+	msg := &govTypes.MsgDAOTransfer{
+		FromAddress: decodedFromAddress,
+		ToAddress:   toAddress,
+		Amount:      coreTypes.NewInt(amount),
+		Action:      action,
+	}
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// NewChangeParam returns message for Change Param transaction, i.e. paramKey pos/StakeMinimum
+// The value is the amino JSON of the param taken by the Pocket CLI, i.e. "15000000000" for pos/StakeMinimum
+// Only the owner of the param in the ACL can sign it
+func NewChangeParam(fromAddress, paramKey string, paramValue json.RawMessage) (TransactionMessage, error) {
+	decodedFromAddress, err := decodeAddress(fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	module, param, found := strings.Cut(paramKey, "/")
+	if !found || module == "" || param == "" {
+		return nil, ErrInvalidParamKey
+	}
+
+	if !json.Valid(paramValue) {
+		return nil, ErrInvalidParamValue
+	}
+
+	encodedValue, err := app.Codec().MarshalJSON(paramValue)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &govTypes.MsgChangeParam{
+		FromAddress: decodedFromAddress,
+		ParamKey:    paramKey,
+		ParamVal:    encodedValue,
+	}
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// NewUpgrade returns message for Upgrade transaction, scheduling the version at the height
+// Features are only used with the FEATURE version, in the form KEY:height
+// Only the upgrade owner in the ACL can sign it
+func NewUpgrade(fromAddress string, height int64, version string, features ...string) (TransactionMessage, error) {
+	decodedFromAddress, err := decodeAddress(fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &govTypes.MsgUpgrade{
+		Address: decodedFromAddress,
+		Upgrade: govTypes.Upgrade{
+			Height:   height,
+			Version:  version,
+			Features: features,
+		},
+	}
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// decodeAddress returns the decoded address checking its length, nil when empty so messages reject it
+func decodeAddress(address string) (coreTypes.Address, error) {
+	if address == "" {
+		return nil, nil
+	}
+
+	decodedAddress, err := hex.DecodeString(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := coreTypes.VerifyAddressFormat(decodedAddress); err != nil {
+		return nil, err
+	}
+
+	return decodedAddress, nil
+}
+
+// stakedChains returns the relay chains of stake messages, nil for other messages
+func stakedChains(txMsg TransactionMessage) []string {
+	switch msg := txMsg.(type) {
+	case *appsType.MsgStake:
+		return msg.Chains
+	case *nodesTypes.MsgStake:
+		return msg.Chains
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package transactionbuilder
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/pokt-network/pocket-core/app"
+	coreTypes "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/auth"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+	govTypes "github.com/pokt-network/pocket-core/x/gov/types"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeTransaction returns the message of a transaction created by the builder
+func decodeTransaction(c *require.Assertions, input *provider.SendTransactionInput) coreTypes.Msg {
+	txBytes, err := hex.DecodeString(input.RawHexBytes)
+	c.NoError(err)
+
+	tx, sdkErr := auth.DefaultTxDecoder(app.Codec())(txBytes, -1)
+	c.Nil(sdkErr)
+
+	return tx.(authTypes.StdTx).Msg
+}
+
+func TestNewGovernanceMessages(t *testing.T) {
+	c := require.New(t)
+
+	signer, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	txBuilder := NewTransactionBuilder(provider.NewProvider("https://dummy.com", []string{"https://dummy.com"}), signer)
+
+	toAddress := "b50a6e20d3733fb89631ae32385b3c85c533c561"
+
+	daoTransfer, err := NewDAOTransfer(signer.GetAddress(), toAddress, 21)
+	c.NoError(err)
+
+	input, err := txBuilder.CreateTransaction(Mainnet, daoTransfer, nil)
+	c.NoError(err)
+
+	msg := decodeTransaction(c, input).(*govTypes.MsgDAOTransfer)
+	c.Equal(signer.GetAddress(), msg.FromAddress.String())
+	c.Equal(toAddress, msg.ToAddress.String())
+	c.Equal(int64(21), msg.Amount.Int64())
+	c.Equal(govTypes.DAOTransferString, msg.Action)
+
+	daoBurn, err := NewDAOBurn(signer.GetAddress(), 21)
+	c.NoError(err)
+
+	input, err = txBuilder.CreateTransaction(Mainnet, daoBurn, nil)
+	c.NoError(err)
+
+	msg = decodeTransaction(c, input).(*govTypes.MsgDAOTransfer)
+	c.Equal(govTypes.DAOBurnString, msg.Action)
+	c.Empty(msg.ToAddress)
+
+	changeParam, err := NewChangeParam(signer.GetAddress(), "pos/StakeMinimum", json.RawMessage(`"15000000000"`))
+	c.NoError(err)
+
+	input, err = txBuilder.CreateTransaction(Mainnet, changeParam, nil)
+	c.NoError(err)
+
+	paramMsg := decodeTransaction(c, input).(*govTypes.MsgChangeParam)
+	c.Equal(signer.GetAddress(), paramMsg.FromAddress.String())
+	c.Equal("pos/StakeMinimum", paramMsg.ParamKey)
+	c.Equal(`"15000000000"`, string(paramMsg.ParamVal))
+
+	upgrade, err := NewUpgrade(signer.GetAddress(), 60000, "FEATURE", "RSCAL:60000")
+	c.NoError(err)
+
+	input, err = txBuilder.CreateTransaction(Mainnet, upgrade, nil)
+	c.NoError(err)
+
+	upgradeMsg := decodeTransaction(c, input).(*govTypes.MsgUpgrade)
+	c.Equal(signer.GetAddress(), upgradeMsg.Address.String())
+	c.Equal(int64(60000), upgradeMsg.Upgrade.Height)
+	c.Equal("FEATURE", upgradeMsg.Upgrade.Version)
+	c.Equal([]string{"RSCAL:60000"}, upgradeMsg.Upgrade.Features)
+}
+
+func TestNewGovernanceMessagesErrors(t *testing.T) {
+	c := require.New(t)
+
+	address := "b50a6e20d3733fb89631ae32385b3c85c533c560"
+
+	_, err := NewDAOTransfer("not-hex", address, 21)
+	c.Error(err)
+
+	_, err = NewDAOTransfer(address, "not-hex", 21)
+	c.Error(err)
+
+	_, err = NewDAOTransfer(address, "", 21)
+	c.Error(err)
+
+	_, err = NewDAOBurn(address, 0)
+	c.Equal(ErrNonPositiveAmount, err)
+
+	_, err = NewDAOBurn(address, -21)
+	c.Equal(ErrNonPositiveAmount, err)
+
+	_, err = NewChangeParam(address, "StakeMinimum", json.RawMessage(`"21"`))
+	c.Equal(ErrInvalidParamKey, err)
+
+	_, err = NewChangeParam(address, "pos/", json.RawMessage(`"21"`))
+	c.Equal(ErrInvalidParamKey, err)
+
+	_, err = NewChangeParam("", "pos/StakeMinimum", json.RawMessage(`"21"`))
+	c.Error(err)
+
+	_, err = NewChangeParam(address, "pos/StakeMinimum", json.RawMessage(`"21`))
+	c.Equal(ErrInvalidParamValue, err)
+
+	_, err = NewUpgrade(address, 0, "RC-0.9.0")
+	c.Error(err)
+
+	_, err = NewUpgrade(address, 60000, "")
+	c.Error(err)
+}
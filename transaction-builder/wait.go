@@ -0,0 +1,190 @@
+package transactionbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/utils"
+)
+
+const (
+	defaultTimeoutBlocks = 5
+	defaultPollInterval  = 10 * time.Second
+	// maxHeightErrors bounds the consecutive failures to get the height, the timeout can't be checked without it
+	maxHeightErrors = 5
+)
+
+// ErrNoTransactionHash error when no transaction hash is provided
+var ErrNoTransactionHash = errors.New("no transaction hash provided")
+
+// TransactionFailedError error when a transaction is included in a block with a non zero result code
+// The fee is still charged for failed transactions, unwraps to the error matching its codespace and code
+type TransactionFailedError struct {
+	Hash      string
+	Height    int
+	Code      int
+	Codespace string
+	Log       string
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *TransactionFailedError) Error() string {
+	return fmt.Sprintf("transaction %s failed at height %d with code %d in codespace %s: %s", e.Hash, e.Height, e.Code, e.Codespace, e.Log)
+}
+
+// Unwrap returns the error matching the codespace and code
+func (e *TransactionFailedError) Unwrap() error {
+	return DecodeFailure(e.Codespace, e.Code, e.Log)
+}
+
+// TransactionTimeoutError error when a transaction is not included before the timeout height
+// The transaction may still be included later, its hash allows to keep tracking it
+type TransactionTimeoutError struct {
+	Hash          string
+	TimeoutHeight int
+}
+
+// Error returns string representation of error
+// needed to implement error interface
+func (e *TransactionTimeoutError) Error() string {
+	return fmt.Sprintf("transaction %s not included by height %d", e.Hash, e.TimeoutHeight)
+}
+
+// WaitOptions represents optional parameters for waiting for a transaction
+type WaitOptions struct {
+	// TimeoutBlocks is the amount of blocks to wait for the transaction, defaults to 5
+	TimeoutBlocks int
+	// PollInterval is the time between polls of the transaction, defaults to 10 seconds
+	PollInterval time.Duration
+}
+
+func getWaitParams(options *WaitOptions) (int, time.Duration) {
+	timeoutBlocks := defaultTimeoutBlocks
+	pollInterval := defaultPollInterval
+
+	if options != nil {
+		if options.TimeoutBlocks > 0 {
+			timeoutBlocks = options.TimeoutBlocks
+		}
+
+		if options.PollInterval > 0 {
+			pollInterval = options.PollInterval
+		}
+	}
+
+	return timeoutBlocks, pollInterval
+}
+
+// SubmitAndWait does the transaction from raw input and waits until it is included in a block
+func (t *TransactionBuilder) SubmitAndWait(chainID ChainID, txMsg TransactionMessage, options *TransactionOptions, waitOptions *WaitOptions) (*provider.Transaction, error) {
+	return t.SubmitAndWaitWithCtx(context.Background(), chainID, txMsg, options, waitOptions)
+}
+
+// SubmitAndWaitWithCtx does the transaction from raw input and waits until it is included in a block
+// Returns TransactionFailedError when it is included with an error and TransactionTimeoutError when it is
+// not included in time
+func (t *TransactionBuilder) SubmitAndWaitWithCtx(ctx context.Context, chainID ChainID, txMsg TransactionMessage, options *TransactionOptions, waitOptions *WaitOptions) (*provider.Transaction, error) {
+	output, err := t.SubmitWithCtx(ctx, chainID, txMsg, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.WaitForTransactionWithCtx(ctx, output.Txhash, waitOptions)
+}
+
+// WaitForTransaction waits until the transaction with the hash is included in a block
+func (t *TransactionBuilder) WaitForTransaction(hash string, waitOptions *WaitOptions) (*provider.Transaction, error) {
+	return t.WaitForTransactionWithCtx(context.Background(), hash, waitOptions)
+}
+
+// WaitForTransactionWithCtx waits until the transaction with the hash is included in a block
+// The timeout blocks count from the height when called, errors of the polls are retried until then
+// but the height failing 5 polls in a row returns its error
+// The provider of the builder must implement TransactionTracker
+func (t *TransactionBuilder) WaitForTransactionWithCtx(ctx context.Context, hash string, waitOptions *WaitOptions) (*provider.Transaction, error) {
+	if t.provider == nil {
+		return nil, ErrNoProvider
+	}
+
+	tracker, ok := t.provider.(TransactionTracker)
+	if !ok {
+		return nil, ErrNoTransactionTracker
+	}
+
+	if hash == "" {
+		return nil, ErrNoTransactionHash
+	}
+
+	timeoutBlocks, pollInterval := getWaitParams(waitOptions)
+
+	startHeight, err := tracker.GetBlockHeightWithCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutHeight := startHeight + timeoutBlocks
+	heightErrors := 0
+
+	for {
+		if transaction := lookupTransaction(ctx, tracker, hash); transaction != nil {
+			return CheckTransactionResult(transaction)
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		height, err := tracker.GetBlockHeightWithCtx(ctx)
+		if err != nil {
+			heightErrors++
+
+			if heightErrors >= maxHeightErrors {
+				return nil, err
+			}
+		} else {
+			heightErrors = 0
+		}
+
+		if err == nil && height >= timeoutHeight {
+			// The transaction may have been included between the poll and the height check
+			if transaction := lookupTransaction(ctx, tracker, hash); transaction != nil {
+				return CheckTransactionResult(transaction)
+			}
+
+			return nil, &TransactionTimeoutError{Hash: hash, TimeoutHeight: timeoutHeight}
+		}
+
+		if err := utils.Sleep(ctx, pollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// lookupTransaction returns the transaction when it is included in a block, nil when it is not or could not be polled
+func lookupTransaction(ctx context.Context, tracker TransactionTracker, hash string) *provider.Transaction {
+	output, err := tracker.GetTransactionWithCtx(ctx, hash, nil)
+	if err != nil {
+		return nil
+	}
+
+	return output.Transaction
+}
+
+// CheckTransactionResult returns the transaction, or a TransactionFailedError when its result code is not zero
+func CheckTransactionResult(transaction *provider.Transaction) (*provider.Transaction, error) {
+	if transaction.TxResult == nil || transaction.TxResult.Code == 0 {
+		return transaction, nil
+	}
+
+	return nil, &TransactionFailedError{
+		Hash:      transaction.Hash,
+		Height:    transaction.Height,
+		Code:      transaction.TxResult.Code,
+		Codespace: transaction.TxResult.Codespace,
+		Log:       transaction.TxResult.Log,
+	}
+}
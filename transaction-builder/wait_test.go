@@ -0,0 +1,174 @@
+package transactionbuilder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	"github.com/stretchr/testify/require"
+)
+
+// commitEvery commits blocks in the background until the returned function is called
+func commitEvery(server *pockettest.Server, interval time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				server.Commit()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// lateTracker includes the transaction right after the first poll, when the height reached the timeout
+type lateTracker struct {
+	Provider
+	polls   int
+	heights []int
+}
+
+func (p *lateTracker) GetTransactionWithCtx(ctx context.Context, transactionHash string, options *provider.GetTransactionOptions) (*provider.GetTransactionOutput, error) {
+	p.polls++
+
+	if p.polls == 1 {
+		return nil, errors.New("transaction not found")
+	}
+
+	return &provider.GetTransactionOutput{Transaction: &provider.Transaction{Hash: transactionHash, Height: 15}}, nil
+}
+
+func (p *lateTracker) GetBlockHeightWithCtx(ctx context.Context) (int, error) {
+	height := p.heights[0]
+	p.heights = p.heights[1:]
+
+	return height, nil
+}
+
+// failingHeightTracker fails to get the height after the first call and never finds the transaction
+type failingHeightTracker struct {
+	Provider
+	heightCalls int
+}
+
+func (p *failingHeightTracker) GetTransactionWithCtx(ctx context.Context, transactionHash string, options *provider.GetTransactionOptions) (*provider.GetTransactionOutput, error) {
+	return nil, errors.New("transaction not found")
+}
+
+func (p *failingHeightTracker) GetBlockHeightWithCtx(ctx context.Context) (int, error) {
+	p.heightCalls++
+
+	if p.heightCalls == 1 {
+		return 10, nil
+	}
+
+	return 0, provider.Err5xxOnConnection
+}
+
+func TestTransactionBuilder_SubmitAndWait(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	receiver, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 100000)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	txBuilder := NewTransactionBuilder(rpcProvider, sender)
+
+	waitOptions := &WaitOptions{TimeoutBlocks: 50, PollInterval: 10 * time.Millisecond}
+
+	msgSend, err := NewSend(sender.GetAddress(), receiver.GetAddress(), 21)
+	c.NoError(err)
+
+	stop := commitEvery(server, 20*time.Millisecond)
+
+	transaction, err := txBuilder.SubmitAndWait(Localnet, msgSend, nil, waitOptions)
+	c.NoError(err)
+	c.Greater(transaction.Height, 1)
+	c.Zero(transaction.TxResult.Code)
+	c.Equal(int64(21), server.Balance(receiver.GetAddress()).Int64())
+
+	// Included transactions failing return their result
+	msgSend, err = NewSend(sender.GetAddress(), receiver.GetAddress(), 1000000)
+	c.NoError(err)
+
+	_, err = txBuilder.SubmitAndWait(Localnet, msgSend, nil, waitOptions)
+
+	var failedErr *TransactionFailedError
+	c.ErrorAs(err, &failedErr)
+	c.Greater(failedErr.Height, transaction.Height)
+	c.NotZero(failedErr.Code)
+	c.Equal("sdk", failedErr.Codespace)
+	c.ErrorIs(err, ErrInsufficientFunds)
+	c.NotEmpty(failedErr.Log)
+
+	stop()
+
+	// Transactions not included before the timeout height
+	height := server.Height()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		server.AdvanceHeight(2)
+	}()
+
+	_, err = txBuilder.WaitForTransaction("ABCD", &WaitOptions{TimeoutBlocks: 2, PollInterval: 10 * time.Millisecond})
+
+	var timeoutErr *TransactionTimeoutError
+	c.ErrorAs(err, &timeoutErr)
+	c.Equal("ABCD", timeoutErr.Hash)
+	c.Equal(height+2, timeoutErr.TimeoutHeight)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = txBuilder.WaitForTransactionWithCtx(ctx, "ABCD", waitOptions)
+	c.ErrorIs(err, context.DeadlineExceeded)
+
+	_, err = txBuilder.WaitForTransaction("", nil)
+	c.Equal(ErrNoTransactionHash, err)
+
+	// Transactions included between the last poll and the timeout height are not reported as timed out
+	tracker := &lateTracker{Provider: rpcProvider, heights: []int{10, 15}}
+
+	transaction, err = NewTransactionBuilder(tracker, sender).WaitForTransaction("ABCD", &WaitOptions{TimeoutBlocks: 5})
+	c.NoError(err)
+	c.Equal(15, transaction.Height)
+	c.Equal(2, tracker.polls)
+
+	// Heights failing in a row stop the wait as the timeout can't be checked
+	heightTracker := &failingHeightTracker{Provider: rpcProvider}
+
+	_, err = NewTransactionBuilder(heightTracker, sender).WaitForTransaction("ABCD", &WaitOptions{PollInterval: time.Millisecond})
+	c.Equal(provider.Err5xxOnConnection, err)
+	c.Equal(1+maxHeightErrors, heightTracker.heightCalls)
+
+	// Providers only sending transactions can not wait for them
+	_, err = NewTransactionBuilder(struct{ Provider }{rpcProvider}, sender).WaitForTransaction("ABCD", nil)
+	c.Equal(ErrNoTransactionTracker, err)
+}
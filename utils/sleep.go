@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep waits for the duration, returning early with the context error when it is done
+func Sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NextBackoff doubles the backoff without going over the max one
+func NextBackoff(backoff, maxBackoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+
+	return backoff
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSleep(t *testing.T) {
+	c := require.New(t)
+
+	c.NoError(Sleep(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c.Equal(context.Canceled, Sleep(ctx, time.Hour))
+}
+
+func TestNextBackoff(t *testing.T) {
+	c := require.New(t)
+
+	c.Equal(2*time.Second, NextBackoff(time.Second, time.Minute))
+	c.Equal(time.Minute, NextBackoff(40*time.Second, time.Minute))
+}
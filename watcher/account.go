@@ -0,0 +1,187 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+)
+
+// ErrNoAddress error when no address is provided
+var ErrNoAddress = errors.New("no address provided")
+
+// AccountFilter represents optional filters of SubscribeAccount
+type AccountFilter struct {
+	// StartHeight backfills the transactions of the account from the height, zero only follows new blocks
+	StartHeight int
+	// MessageTypes limits the transactions to the message types, i.e. pos/Send or apps/MsgAppStake
+	MessageTypes []string
+	// SkipFailed leaves out transactions included in a block with a non zero result code
+	SkipFailed bool
+}
+
+func (f *AccountFilter) matches(address string, transaction *provider.Transaction) bool {
+	if transaction.TxResult == nil {
+		return false
+	}
+
+	if !strings.EqualFold(transaction.TxResult.Signer, address) && !strings.EqualFold(transaction.TxResult.Recipient, address) {
+		return false
+	}
+
+	if f.SkipFailed && transaction.TxResult.Code != 0 {
+		return false
+	}
+
+	if len(f.MessageTypes) == 0 {
+		return true
+	}
+
+	if transaction.StdTx == nil || transaction.StdTx.Msg == nil {
+		return false
+	}
+
+	for _, messageType := range f.MessageTypes {
+		if transaction.StdTx.Msg.Type == messageType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SubscribeAccount sends every transaction where the address is signer or recipient to the returned channel
+// Transactions from the filter start height up to the current height are backfilled with the account
+// transactions, later ones are taken from every new block. Transactions come in height and index order
+// and the channel is closed when the context is done
+func (w *Watcher) SubscribeAccount(ctx context.Context, address string, filter *AccountFilter) (<-chan *provider.Transaction, error) {
+	if address == "" {
+		return nil, ErrNoAddress
+	}
+
+	if filter == nil {
+		filter = &AccountFilter{}
+	}
+
+	transactions := make(chan *provider.Transaction)
+
+	go func() {
+		defer close(transactions)
+
+		_ = w.subscribeAccount(ctx, address, filter, transactions)
+	}()
+
+	return transactions, nil
+}
+
+func (w *Watcher) subscribeAccount(ctx context.Context, address string, filter *AccountFilter, transactions chan<- *provider.Transaction) error {
+	currentHeight, err := w.currentHeight(ctx)
+	if err != nil {
+		return err
+	}
+
+	liveHeight := currentHeight + 1
+
+	if filter.StartHeight > currentHeight {
+		liveHeight = filter.StartHeight
+	}
+
+	if filter.StartHeight > 0 && filter.StartHeight <= currentHeight {
+		var backfill []*provider.Transaction
+
+		err := w.retry(ctx, func() error {
+			var err error
+
+			backfill, err = w.accountTransactions(ctx, address, filter.StartHeight, currentHeight)
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := sendMatching(ctx, address, filter, backfill, transactions); err != nil {
+			return err
+		}
+	}
+
+	live := *w
+	live.checkpoint = nil
+	live.startHeight = liveHeight
+	live.skipBlocks = true
+	live.skipTransactions = false
+	live.onGap = nil
+
+	return live.Run(ctx, func(ctx context.Context, event *Event) error {
+		return sendMatching(ctx, address, filter, event.Transactions, transactions)
+	})
+}
+
+// accountTransactions returns the transactions sent and received by the address between the heights
+// sorted by height and index
+// The pages come newest first so paging stops at the first transaction below fromHeight
+func (w *Watcher) accountTransactions(ctx context.Context, address string, fromHeight, toHeight int) ([]*provider.Transaction, error) {
+	byHash := map[string]*provider.Transaction{}
+
+	for _, received := range []bool{false, true} {
+		for page, done := 1, false; !done; page++ {
+			output, err := w.provider.GetAccountTransactionsWithCtx(ctx, address, &provider.GetAccountTransactionsOptions{
+				Page:     page,
+				PerPage:  w.perPage,
+				Received: received,
+				Order:    provider.DescendantOrder,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, transaction := range output.Txs {
+				if transaction.Height < fromHeight {
+					done = true
+					break
+				}
+
+				if transaction.Height <= toHeight {
+					byHash[transaction.Hash] = transaction
+				}
+			}
+
+			if len(output.Txs) == 0 || page*w.perPage >= output.TotalTxs {
+				done = true
+			}
+		}
+	}
+
+	transactions := make([]*provider.Transaction, 0, len(byHash))
+	for _, transaction := range byHash {
+		transactions = append(transactions, transaction)
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].Height != transactions[j].Height {
+			return transactions[i].Height < transactions[j].Height
+		}
+
+		return transactions[i].Index < transactions[j].Index
+	})
+
+	return transactions, nil
+}
+
+func sendMatching(ctx context.Context, address string, filter *AccountFilter, transactions []*provider.Transaction, out chan<- *provider.Transaction) error {
+	for _, transaction := range transactions {
+		if !filter.matches(address, transaction) {
+			continue
+		}
+
+		select {
+		case out <- transaction:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+	"github.com/stretchr/testify/require"
+)
+
+func submit(c *require.Assertions, rpcProvider *provider.Provider, sender *signer.Signer, msg transactionbuilder.TransactionMessage) string {
+	output, err := transactionbuilder.NewTransactionBuilder(rpcProvider, sender).Submit(transactionbuilder.Localnet, msg, nil)
+	c.NoError(err)
+
+	return output.Txhash
+}
+
+func newSend(c *require.Assertions, from, to *signer.Signer, amount int64) transactionbuilder.TransactionMessage {
+	msg, err := transactionbuilder.NewSend(from.GetAddress(), to.GetAddress(), amount)
+	c.NoError(err)
+
+	return msg
+}
+
+func receiveTransaction(c *require.Assertions, transactions <-chan *provider.Transaction) *provider.Transaction {
+	select {
+	case transaction := <-transactions:
+		return transaction
+	case <-time.After(5 * time.Second):
+		c.FailNow("no transaction received")
+		return nil
+	}
+}
+
+func TestWatcher_SubscribeAccount(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	account, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 1000000000)
+	server.SetBalance(account.GetAddress(), 1000000000)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+
+	// Transactions before the start height and from other accounts are left out
+	submit(c, rpcProvider, sender, newSend(c, sender, account, 1))
+	server.Commit()
+
+	received := submit(c, rpcProvider, sender, newSend(c, sender, account, 2))
+	submit(c, rpcProvider, sender, newSend(c, sender, sender, 3))
+	sent := submit(c, rpcProvider, account, newSend(c, account, sender, 4))
+	server.Commit()
+
+	watcher, err := NewWatcher(Config{Provider: rpcProvider, PollInterval: 10 * time.Millisecond, PerPage: 1})
+	c.NoError(err)
+
+	_, err = watcher.SubscribeAccount(context.Background(), "", nil)
+	c.Equal(ErrNoAddress, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	transactions, err := watcher.SubscribeAccount(ctx, account.GetAddress(), &AccountFilter{StartHeight: 3})
+	c.NoError(err)
+
+	transaction := receiveTransaction(c, transactions)
+	c.Equal(received, transaction.Hash)
+	c.Equal(account.GetAddress(), transaction.TxResult.Recipient)
+
+	transaction = receiveTransaction(c, transactions)
+	c.Equal(sent, transaction.Hash)
+	c.Equal(account.GetAddress(), transaction.TxResult.Signer)
+
+	// New blocks are followed once backfilled
+	live := submit(c, rpcProvider, sender, newSend(c, sender, account, 5))
+	server.Commit()
+
+	transaction = receiveTransaction(c, transactions)
+	c.Equal(live, transaction.Hash)
+	c.Equal(4, transaction.Height)
+
+	cancel()
+
+	for range transactions {
+	}
+
+	// Message types filter both backfilled and new transactions
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	transactions, err = watcher.SubscribeAccount(ctx, account.GetAddress(), &AccountFilter{
+		StartHeight:  1,
+		MessageTypes: []string{"apps/MsgAppStake"},
+	})
+	c.NoError(err)
+
+	stakeApp, err := transactionbuilder.NewStakeApp(account.GetPublicKey(), []string{"0021"}, 10000000)
+	c.NoError(err)
+
+	stake := submit(c, rpcProvider, account, stakeApp)
+	server.Commit()
+
+	transaction = receiveTransaction(c, transactions)
+	c.Equal(stake, transaction.Hash)
+	c.Equal("apps/MsgAppStake", transaction.StdTx.Msg.Type)
+}
+
+type accountPagesProvider struct {
+	*provider.Provider
+	pages int
+}
+
+func (p *accountPagesProvider) GetAccountTransactionsWithCtx(ctx context.Context, address string, options *provider.GetAccountTransactionsOptions) (*provider.GetAccountTransactionsOutput, error) {
+	p.pages++
+
+	return p.Provider.GetAccountTransactionsWithCtx(ctx, address, options)
+}
+
+func TestWatcher_AccountTransactions(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	account, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 1000000000)
+
+	rpcProvider := &accountPagesProvider{Provider: provider.NewProvider(server.URL, []string{server.URL})}
+
+	var hashes []string
+
+	for i := 0; i < 4; i++ {
+		hashes = append(hashes, submit(c, rpcProvider.Provider, sender, newSend(c, sender, account, int64(i+1))))
+		server.Commit()
+	}
+
+	watcher, err := NewWatcher(Config{Provider: rpcProvider, PerPage: 1})
+	c.NoError(err)
+
+	transactions, err := watcher.accountTransactions(context.Background(), account.GetAddress(), 4, 5)
+	c.NoError(err)
+	c.Len(transactions, 2)
+	c.Equal(hashes[2], transactions[0].Hash)
+	c.Equal(hashes[3], transactions[1].Hash)
+
+	// Paging stops at the first transaction below the height instead of reading the whole history
+	c.Equal(4, rpcProvider.pages)
+}
@@ -0,0 +1,69 @@
+package watcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Checkpoint interface representing the storage of the last height handled by a Watcher
+type Checkpoint interface {
+	// Load returns the last handled height, zero when there is none
+	Load() (int, error)
+	Save(height int) error
+}
+
+// FileCheckpoint is a Checkpoint that keeps the height in a file
+type FileCheckpoint struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCheckpoint returns an instance of FileCheckpoint storing the height in path
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load returns the height in the file, zero when the file does not exist
+func (c *FileCheckpoint) Load() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// Save writes the height to the file, replacing it at once so a crash never leaves it half written
+func (c *FileCheckpoint) Save(height int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tempFile, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString(strconv.Itoa(height) + "\n"); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), c.path)
+}
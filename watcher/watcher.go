@@ -0,0 +1,346 @@
+// Package watcher follows the blocks of the Pocket network, emitting every new block with its transactions
+// Underneath uses the package Provider
+package watcher
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/utils"
+)
+
+const (
+	defaultPollInterval = 30 * time.Second
+	defaultPerPage      = 100
+	defaultMinBackoff   = time.Second
+	defaultMaxBackoff   = time.Minute
+)
+
+var (
+	// ErrNoProvider error when no provider is provided
+	ErrNoProvider = errors.New("no provider provided")
+	// ErrMissingTransactions error when a block returns less transactions than it reports
+	ErrMissingTransactions = errors.New("block transactions missing from pages")
+)
+
+// Provider interface representing provider functions necessary for Watcher Package
+type Provider interface {
+	GetBlockHeightWithCtx(ctx context.Context) (int, error)
+	GetBlockWithCtx(ctx context.Context, blockNumber int) (*provider.GetBlockOutput, error)
+	GetBlockTransactionsWithCtx(ctx context.Context, options *provider.GetBlockTransactionsOptions) (*provider.GetBlockTransactionsOutput, error)
+	GetAccountTransactionsWithCtx(ctx context.Context, address string, options *provider.GetAccountTransactionsOptions) (*provider.GetAccountTransactionsOutput, error)
+}
+
+// Event represents a new block seen by the Watcher
+type Event struct {
+	Height int
+	// Block is nil when Config.SkipBlocks is set
+	Block *provider.GetBlockOutput
+	// Transactions are sorted by index, nil when Config.SkipTransactions is set
+	Transactions []*provider.Transaction
+}
+
+// Handler is called with every new block in height order
+type Handler func(ctx context.Context, event *Event) error
+
+// Config represents the configuration of a Watcher
+type Config struct {
+	Provider Provider
+	// Checkpoint persists the last handled height so the Watcher resumes after it, optional
+	Checkpoint Checkpoint
+	// StartHeight is the first height handled when there is no checkpoint, defaults to the current height
+	StartHeight int
+	// PollInterval is the time between height polls once caught up, defaults to 30 seconds
+	PollInterval time.Duration
+	// PerPage is the amount of transactions requested per page, defaults to 100
+	PerPage int
+	// MinBackoff and MaxBackoff bound the exponential wait after errors, default to 1 second and 1 minute
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// SkipBlocks and SkipTransactions avoid requesting what is not needed
+	SkipBlocks       bool
+	SkipTransactions bool
+	// OnGap is called with the heights the network advanced at once since the last poll, i.e. after a
+	// downtime, the Watcher still handles every height of the gap in order
+	OnGap func(from, to int)
+	// OnError is called with every error before backing off, including the errors returned by the Handler
+	OnError func(err error)
+}
+
+// Watcher polls the block height and handles every new block in order
+// Blocks are delivered at least once, a height is retried until its Handler returns no error
+type Watcher struct {
+	provider         Provider
+	checkpoint       Checkpoint
+	startHeight      int
+	pollInterval     time.Duration
+	perPage          int
+	minBackoff       time.Duration
+	maxBackoff       time.Duration
+	skipBlocks       bool
+	skipTransactions bool
+	onGap            func(from, to int)
+	onError          func(err error)
+}
+
+// NewWatcher returns an instance of Watcher from the given config
+func NewWatcher(config Config) (*Watcher, error) {
+	if config.Provider == nil {
+		return nil, ErrNoProvider
+	}
+
+	watcher := &Watcher{
+		provider:         config.Provider,
+		checkpoint:       config.Checkpoint,
+		startHeight:      config.StartHeight,
+		pollInterval:     config.PollInterval,
+		perPage:          config.PerPage,
+		minBackoff:       config.MinBackoff,
+		maxBackoff:       config.MaxBackoff,
+		skipBlocks:       config.SkipBlocks,
+		skipTransactions: config.SkipTransactions,
+		onGap:            config.OnGap,
+		onError:          config.OnError,
+	}
+
+	if watcher.pollInterval <= 0 {
+		watcher.pollInterval = defaultPollInterval
+	}
+
+	if watcher.perPage <= 0 {
+		watcher.perPage = defaultPerPage
+	}
+
+	if watcher.minBackoff <= 0 {
+		watcher.minBackoff = defaultMinBackoff
+	}
+
+	if watcher.maxBackoff <= 0 {
+		watcher.maxBackoff = defaultMaxBackoff
+	}
+
+	if watcher.maxBackoff < watcher.minBackoff {
+		watcher.maxBackoff = watcher.minBackoff
+	}
+
+	return watcher, nil
+}
+
+// Run handles every new block until the context is done, returning the context error
+func (w *Watcher) Run(ctx context.Context, handler Handler) error {
+	nextHeight, err := w.loadNextHeight(ctx)
+	if err != nil {
+		return err
+	}
+
+	backoff := w.minBackoff
+	lastSeenHeight := nextHeight - 1
+
+	for {
+		caughtUp, currentHeight, err := w.handleNext(ctx, nextHeight, lastSeenHeight, handler)
+		lastSeenHeight = currentHeight
+
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			w.reportError(err)
+
+			if err := utils.Sleep(ctx, backoff); err != nil {
+				return err
+			}
+
+			backoff = utils.NextBackoff(backoff, w.maxBackoff)
+
+			continue
+		}
+
+		backoff = w.minBackoff
+
+		if caughtUp {
+			if err := utils.Sleep(ctx, w.pollInterval); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		nextHeight++
+	}
+}
+
+// Events runs the Watcher in the background and sends every new block to the returned channel
+// The checkpoint is saved once the event is received, the channel is closed when the context is done
+func (w *Watcher) Events(ctx context.Context) <-chan *Event {
+	events := make(chan *Event)
+
+	go func() {
+		defer close(events)
+
+		_ = w.Run(ctx, func(ctx context.Context, event *Event) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return events
+}
+
+// loadNextHeight returns the first height to handle, after the checkpoint or the start height
+func (w *Watcher) loadNextHeight(ctx context.Context) (int, error) {
+	if w.checkpoint != nil {
+		height, err := w.checkpoint.Load()
+		if err != nil {
+			return 0, err
+		}
+
+		if height > 0 {
+			return height + 1, nil
+		}
+	}
+
+	if w.startHeight > 0 {
+		return w.startHeight, nil
+	}
+
+	return w.currentHeight(ctx)
+}
+
+// currentHeight returns the height of the network, retrying until it gets it or the context is done
+func (w *Watcher) currentHeight(ctx context.Context) (int, error) {
+	var height int
+
+	err := w.retry(ctx, func() error {
+		var err error
+
+		height, err = w.provider.GetBlockHeightWithCtx(ctx)
+
+		return err
+	})
+
+	return height, err
+}
+
+// retry calls fn until it succeeds backing off after each error, fails only when the context is done
+func (w *Watcher) retry(ctx context.Context, fn func() error) error {
+	for backoff := w.minBackoff; ; backoff = utils.NextBackoff(backoff, w.maxBackoff) {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		w.reportError(err)
+
+		if err := utils.Sleep(ctx, backoff); err != nil {
+			return err
+		}
+	}
+}
+
+// handleNext handles the height when the network reached it, returns true when the Watcher is caught up
+// and the current height of the network, lastSeenHeight when it was not or could not be polled
+// The height is only polled past lastSeenHeight, heights below it are known to be reached while catching up
+func (w *Watcher) handleNext(ctx context.Context, height, lastSeenHeight int, handler Handler) (bool, int, error) {
+	currentHeight := lastSeenHeight
+
+	if height > lastSeenHeight {
+		var err error
+
+		currentHeight, err = w.provider.GetBlockHeightWithCtx(ctx)
+		if err != nil {
+			return false, lastSeenHeight, err
+		}
+
+		if currentHeight < height {
+			return true, currentHeight, nil
+		}
+
+		if w.onGap != nil && currentHeight > lastSeenHeight+1 {
+			w.onGap(lastSeenHeight+1, currentHeight)
+		}
+	}
+
+	event, err := w.fetchEvent(ctx, height)
+	if err != nil {
+		return false, currentHeight, err
+	}
+
+	if err := handler(ctx, event); err != nil {
+		return false, currentHeight, err
+	}
+
+	if w.checkpoint != nil {
+		if err := w.checkpoint.Save(height); err != nil {
+			return false, currentHeight, err
+		}
+	}
+
+	return false, currentHeight, nil
+}
+
+func (w *Watcher) fetchEvent(ctx context.Context, height int) (*Event, error) {
+	event := &Event{Height: height}
+
+	if !w.skipBlocks {
+		block, err := w.provider.GetBlockWithCtx(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Block = block
+	}
+
+	if !w.skipTransactions {
+		transactions, err := w.fetchTransactions(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Transactions = transactions
+	}
+
+	return event, nil
+}
+
+// fetchTransactions returns every transaction of the block going through its pages
+func (w *Watcher) fetchTransactions(ctx context.Context, height int) ([]*provider.Transaction, error) {
+	transactions := []*provider.Transaction{}
+
+	for page := 1; ; page++ {
+		output, err := w.provider.GetBlockTransactionsWithCtx(ctx, &provider.GetBlockTransactionsOptions{
+			Height:  height,
+			Page:    page,
+			PerPage: w.perPage,
+			Order:   provider.AscendantOrder,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, output.Txs...)
+
+		if len(transactions) >= output.TotalTxs {
+			return transactions, nil
+		}
+
+		if len(output.Txs) == 0 {
+			return nil, ErrMissingTransactions
+		}
+	}
+}
+
+func (w *Watcher) reportError(err error) {
+	if w.onError != nil {
+		w.onError(err)
+	}
+}
@@ -0,0 +1,238 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/pocket-go/pockettest"
+	"github.com/pokt-foundation/pocket-go/provider"
+	"github.com/pokt-foundation/pocket-go/signer"
+	transactionbuilder "github.com/pokt-foundation/pocket-go/transaction-builder"
+	"github.com/stretchr/testify/require"
+)
+
+func sendTransactions(c *require.Assertions, rpcProvider *provider.Provider, sender *signer.Signer, count int) {
+	builder := transactionbuilder.NewTransactionBuilder(rpcProvider, sender)
+
+	for i := 0; i < count; i++ {
+		msg, err := transactionbuilder.NewSend(sender.GetAddress(), sender.GetAddress(), int64(i+1))
+		c.NoError(err)
+
+		_, err = builder.Submit(transactionbuilder.Localnet, msg, nil)
+		c.NoError(err)
+	}
+}
+
+func receive(c *require.Assertions, events <-chan *Event) *Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		c.FailNow("no event received")
+		return nil
+	}
+}
+
+func TestNewWatcher(t *testing.T) {
+	c := require.New(t)
+
+	_, err := NewWatcher(Config{})
+	c.Equal(ErrNoProvider, err)
+}
+
+func TestWatcher_Events(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	sender, err := signer.NewRandomSigner()
+	c.NoError(err)
+
+	server.SetBalance(sender.GetAddress(), 1000000)
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+
+	sendTransactions(c, rpcProvider, sender, 3)
+	server.Commit()
+	server.Commit()
+
+	checkpoint := NewFileCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+
+	var gaps [][2]int
+
+	config := Config{
+		Provider:     rpcProvider,
+		Checkpoint:   checkpoint,
+		StartHeight:  2,
+		PollInterval: 10 * time.Millisecond,
+		PerPage:      2,
+		OnGap:        func(from, to int) { gaps = append(gaps, [2]int{from, to}) },
+	}
+
+	watcher, err := NewWatcher(config)
+	c.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := watcher.Events(ctx)
+
+	event := receive(c, events)
+	c.Equal(2, event.Height)
+	c.Equal("2", event.Block.Block.Header.Height)
+	c.Len(event.Transactions, 3)
+	c.Equal(0, event.Transactions[0].Index)
+	c.Equal(2, event.Transactions[2].Index)
+
+	event = receive(c, events)
+	c.Equal(3, event.Height)
+	c.Empty(event.Transactions)
+
+	cancel()
+
+	for range events {
+	}
+
+	c.Equal([][2]int{{2, 3}}, gaps)
+
+	height, err := checkpoint.Load()
+	c.NoError(err)
+	c.Equal(3, height)
+
+	// A new watcher resumes after the checkpoint, ignoring the start height
+	server.Commit()
+
+	config.SkipBlocks = true
+
+	watcher, err = NewWatcher(config)
+	c.NoError(err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	events = watcher.Events(ctx)
+
+	event = receive(c, events)
+	c.Equal(4, event.Height)
+	c.Nil(event.Block)
+
+	sendTransactions(c, rpcProvider, sender, 1)
+	server.Commit()
+
+	event = receive(c, events)
+	c.Equal(5, event.Height)
+	c.Len(event.Transactions, 1)
+}
+
+type heightCounterProvider struct {
+	*provider.Provider
+	polls int
+}
+
+func (p *heightCounterProvider) GetBlockHeightWithCtx(ctx context.Context) (int, error) {
+	p.polls++
+
+	return p.Provider.GetBlockHeightWithCtx(ctx)
+}
+
+func TestWatcher_RunCatchUp(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{})
+	defer server.Close()
+
+	for i := 0; i < 4; i++ {
+		server.Commit()
+	}
+
+	rpcProvider := &heightCounterProvider{Provider: provider.NewProvider(server.URL, []string{server.URL})}
+
+	watcher, err := NewWatcher(Config{Provider: rpcProvider, StartHeight: 2, PollInterval: time.Millisecond})
+	c.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var polls []int
+
+	err = watcher.Run(ctx, func(ctx context.Context, event *Event) error {
+		polls = append(polls, rpcProvider.polls)
+
+		if event.Height == 5 {
+			cancel()
+		}
+
+		return nil
+	})
+	c.ErrorIs(err, context.Canceled)
+
+	// The height is polled once for the heights the network already reached
+	c.Equal([]int{1, 1, 1, 1}, polls)
+}
+
+func TestWatcher_RunErrors(t *testing.T) {
+	c := require.New(t)
+
+	server := pockettest.NewServer(pockettest.Config{StartHeight: 3})
+	defer server.Close()
+
+	rpcProvider := provider.NewProvider(server.URL, []string{server.URL})
+	rpcProvider.UpdateRequestConfig(provider.RequestConfigOpts{
+		Transport: pockettest.NewFaultTransport(nil, pockettest.Fault{
+			Route: provider.QueryBlockRoute,
+			Err:   pockettest.ErrConnectionReset,
+			Times: 2,
+		}),
+	})
+
+	var (
+		mu         sync.Mutex
+		errs       []error
+		errHandler = errors.New("handler failed")
+	)
+
+	watcher, err := NewWatcher(Config{
+		Provider:     rpcProvider,
+		PollInterval: 10 * time.Millisecond,
+		MinBackoff:   time.Millisecond,
+		MaxBackoff:   5 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			errs = append(errs, err)
+		},
+	})
+	c.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var heights []int
+
+	err = watcher.Run(ctx, func(ctx context.Context, event *Event) error {
+		heights = append(heights, event.Height)
+
+		if len(heights) == 1 {
+			return errHandler
+		}
+
+		cancel()
+
+		return nil
+	})
+	c.ErrorIs(err, context.Canceled)
+
+	// Starts at the current height and retries it until the handler succeeds
+	c.Equal([]int{3, 3}, heights)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	c.Len(errs, 3)
+	c.ErrorContains(errs[0], "connection reset by peer")
+	c.Equal(errHandler, errs[2])
+}